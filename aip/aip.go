@@ -0,0 +1,154 @@
+// Package aip implements the pagination of gRPC services following Google AIP-158, along with the protobuf
+// representation of the pagination objects.
+package aip
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative streamtune_data.proto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// DefaultPageSize is the page size used when the request has none
+// MaxPageSize is the maximum page size, greater values are coerced to it
+const (
+	DefaultPageSize int32 = 10
+	MaxPageSize     int32 = 1000
+)
+
+// ErrInvalidPageSize is returned when a negative page_size is provided
+// ErrInvalidPageToken is returned when the page_token was not issued by NextPageToken
+// ErrTokenMismatch is returned when the order_by or filter changed since the page_token was issued
+// ErrInvalidOrderBy is returned when the order_by value is not valid
+var (
+	ErrInvalidPageSize  = errors.New("Invalid page_size provided: expected a value greater or equal than 0")
+	ErrInvalidPageToken = errors.New("Invalid page_token provided")
+	ErrTokenMismatch    = errors.New("Invalid page_token provided: order_by and filter must not change between page requests")
+	ErrInvalidOrderBy   = errors.New("Invalid order_by value provided: expected <p1> [asc|desc],...,<pN> [asc|desc]")
+)
+
+// Params contains the default page sizes, whose zero values are replaced by the package defaults
+type Params struct {
+	DefaultPageSize int32
+	MaxPageSize     int32
+}
+
+var defaultParams = Params{DefaultPageSize: DefaultPageSize, MaxPageSize: MaxPageSize}
+
+// Request holds the pagination fields of a List request
+type Request struct {
+	PageSize  int32
+	PageToken string
+	OrderBy   string
+	Filter    string
+}
+
+// ParseRequest converts the provided request fields into an offset based Pageable with default parameters
+func ParseRequest(req Request) (*data.Pageable, error) {
+	return ParseRequestWithParams(req, defaultParams)
+}
+
+// ParseRequestWithParams converts the provided request fields into an offset based Pageable given the provided
+// parameters. The page token must have been issued for the same order_by and filter of the request, while the page
+// size may change between requests.
+func ParseRequestWithParams(req Request, params Params) (*data.Pageable, error) {
+	params = withDefaults(params)
+	size := req.PageSize
+	switch {
+	case size < 0:
+		return nil, ErrInvalidPageSize
+	case size == 0:
+		size = params.DefaultPageSize
+	case params.MaxPageSize > 0 && size > params.MaxPageSize:
+		size = params.MaxPageSize
+	}
+	sort, err := ParseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	var offset int64
+	if req.PageToken != "" {
+		token, err := decodeToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(token.GetChecksum(), checksum(req.OrderBy, req.Filter)) {
+			return nil, ErrTokenMismatch
+		}
+		offset = token.GetOffset()
+	}
+	return data.NewOffsetPageable(offset, int(size), sort), nil
+}
+
+// withDefaults fills the zero page sizes with the default ones
+func withDefaults(params Params) Params {
+	if params.DefaultPageSize == 0 {
+		params.DefaultPageSize = defaultParams.DefaultPageSize
+	}
+	if params.MaxPageSize == 0 {
+		params.MaxPageSize = defaultParams.MaxPageSize
+	}
+	return params
+}
+
+// ParseOrderBy converts an AIP-132 order_by value, like "name desc, create_time", into a Sort object
+func ParseOrderBy(orderBy string) (*data.Sort, error) {
+	sort := data.Unsorted()
+	if strings.TrimSpace(orderBy) == "" {
+		return sort, nil
+	}
+	for _, item := range strings.Split(orderBy, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, ErrInvalidOrderBy
+		}
+		direction := data.Asc
+		if len(parts) == 2 {
+			parsed, err := data.ParseDirection(parts[1])
+			if err != nil {
+				return nil, ErrInvalidOrderBy
+			}
+			direction = parsed
+		}
+		sort = sort.And(data.SortBy(direction, parts[0]))
+	}
+	return sort, nil
+}
+
+// NextPageToken returns the next_page_token for the provided page, requested with given order_by and filter.
+// It's empty when the page is the last one.
+func NextPageToken(page *data.Page, orderBy, filter string) string {
+	if !page.HasNext() {
+		return ""
+	}
+	return encodeToken(&PageToken{Offset: page.NextPageable().Offset(), Checksum: checksum(orderBy, filter)})
+}
+
+func encodeToken(token *PageToken) string {
+	encoded, _ := proto.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeToken(value string) (*PageToken, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	token := &PageToken{}
+	if err := proto.Unmarshal(decoded, token); err != nil || token.GetOffset() < 0 {
+		return nil, ErrInvalidPageToken
+	}
+	return token, nil
+}
+
+// checksum identifies the order_by and filter of a request
+func checksum(orderBy, filter string) []byte {
+	sum := sha256.Sum256([]byte(orderBy + "\x00" + filter))
+	return sum[:8]
+}
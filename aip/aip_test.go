@@ -0,0 +1,77 @@
+package aip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestParseRequestFirstPage(t *testing.T) {
+	pageable, err := ParseRequest(Request{PageSize: 25, OrderBy: "name desc, create_time"})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(0), pageable.Offset())
+	assert.Equal(25, pageable.Size)
+	assert.Equal(data.NewSort(data.OrderBy("name", data.Desc), data.OrderByProperty("create_time")), pageable.Sort)
+}
+
+func TestParseRequestPageSizes(t *testing.T) {
+	defaulted, _ := ParseRequest(Request{})
+	coerced, _ := ParseRequest(Request{PageSize: 5000})
+	_, err := ParseRequest(Request{PageSize: -1})
+
+	assert := assert.New(t)
+	assert.Equal(int(DefaultPageSize), defaulted.Size)
+	assert.Equal(int(MaxPageSize), coerced.Size)
+	assert.Equal(ErrInvalidPageSize, err)
+}
+
+func TestParseRequestWithPartialParams(t *testing.T) {
+	defaulted, err := ParseRequestWithParams(Request{}, Params{MaxPageSize: 50})
+	coerced, _ := ParseRequestWithParams(Request{PageSize: 5000}, Params{DefaultPageSize: 20})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Nil(defaulted.Validate())
+	assert.Equal(int(DefaultPageSize), defaulted.Size)
+	assert.Equal(int(MaxPageSize), coerced.Size)
+}
+
+func TestNextPageTokenRoundTrip(t *testing.T) {
+	request := Request{PageSize: 2, OrderBy: "name", Filter: "age > 3"}
+	pageable, _ := ParseRequest(request)
+	page, _ := data.NewPage([]string{"a", "b"}, pageable, 5)
+	request.PageToken = NextPageToken(page, request.OrderBy, request.Filter)
+	request.PageSize = 3
+	next, err := ParseRequest(request)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(2), next.Offset())
+	assert.Equal(3, next.Size)
+}
+
+func TestNextPageTokenOnLastPage(t *testing.T) {
+	page, _ := data.NewPage([]string{"a"}, data.NewOffsetPageable(4, 2, nil), 5)
+
+	assert.Equal(t, "", NextPageToken(page, "", ""))
+}
+
+func TestParseRequestWithChangedFilter(t *testing.T) {
+	page, _ := data.NewPage([]string{"a", "b"}, data.NewOffsetPageable(0, 2, nil), 5)
+	token := NextPageToken(page, "name", "age > 3")
+	_, err := ParseRequest(Request{PageToken: token, OrderBy: "name", Filter: "age > 4"})
+
+	assert.Equal(t, ErrTokenMismatch, err)
+}
+
+func TestParseRequestWithInvalidValues(t *testing.T) {
+	_, token := ParseRequest(Request{PageToken: "!!"})
+	_, orderBy := ParseRequest(Request{OrderBy: "name sideways"})
+
+	assert := assert.New(t)
+	assert.Equal(ErrInvalidPageToken, token)
+	assert.Equal(ErrInvalidOrderBy, orderBy)
+}
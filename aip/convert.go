@@ -0,0 +1,118 @@
+package aip
+
+import (
+	"math"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// SortToProto converts the provided Sort object into its protobuf message
+func SortToProto(sort *data.Sort) *Sort {
+	message := &Sort{}
+	if sort == nil {
+		return message
+	}
+	for _, order := range sort.Orders {
+		message.Orders = append(message.Orders, &Order{
+			Property:     order.Property,
+			Direction:    directionToProto(order.Direction),
+			IgnoreCase:   order.IgnoreCase,
+			NullHandling: nullHandlingToProto(order.NullHandling),
+		})
+	}
+	return message
+}
+
+// SortFromProto converts the provided protobuf message into a Sort object
+func SortFromProto(message *Sort) *data.Sort {
+	sort := data.Unsorted()
+	for _, o := range message.GetOrders() {
+		order := data.OrderBy(o.GetProperty(), data.Asc)
+		if o.GetDirection() == Direction_DESC {
+			order = order.WithDirection(data.Desc)
+		}
+		if o.GetIgnoreCase() {
+			order = order.WithIgnoreCase()
+		}
+		switch o.GetNullHandling() {
+		case NullHandling_NULLS_FIRST:
+			order = order.NullsFirst()
+		case NullHandling_NULLS_LAST:
+			order = order.NullsLast()
+		}
+		sort.Orders = append(sort.Orders, order)
+	}
+	return sort
+}
+
+// PageableToProto converts the provided Pageable object into its protobuf message
+func PageableToProto(pageable *data.Pageable) *Pageable {
+	if pageable.IsUnpaged() {
+		return &Pageable{Unpaged: true, Sort: SortToProto(pageable.SortOrUnsorted())}
+	}
+	message := &Pageable{Page: toInt32(pageable.Page), Size: toInt32(pageable.Size), Sort: SortToProto(pageable.Sort)}
+	if pageable.IsOffsetBased() {
+		message.Offset = pageable.Offset()
+		message.OffsetBased = true
+	}
+	return message
+}
+
+// PageableFromProto converts the provided protobuf message into a Pageable object, validating its values
+func PageableFromProto(message *Pageable) (*data.Pageable, error) {
+	sort := SortFromProto(message.GetSort())
+	if message.GetUnpaged() {
+		return data.UnpagedWithSort(sort), nil
+	}
+	if message.GetOffsetBased() {
+		if message.GetOffset() < 0 {
+			return nil, data.ErrInvalidPage
+		}
+		pageable := data.NewOffsetPageable(message.GetOffset(), int(message.GetSize()), sort)
+		if err := pageable.Validate(); err != nil {
+			return nil, err
+		}
+		return pageable, nil
+	}
+	return data.NewValidSortedPageable(int(message.GetPage()), int(message.GetSize()), sort)
+}
+
+// PageInfoFromPage converts the pagination details of provided page into a protobuf message
+func PageInfoFromPage(page *data.Page, nextPageToken string) *PageInfo {
+	return &PageInfo{
+		Number:            toInt32(page.Number),
+		Size:              toInt32(page.Size),
+		TotalPages:        toInt32(page.TotalPages),
+		TotalElements:     page.TotalElements,
+		TotalIsLowerBound: page.TotalIsLowerBound,
+		TotalIsEstimated:  page.TotalIsEstimated,
+		Sort:              SortToProto(page.Sort),
+		NextPageToken:     nextPageToken,
+	}
+}
+
+func directionToProto(direction data.Direction) Direction {
+	if direction == data.Desc {
+		return Direction_DESC
+	}
+	return Direction_ASC
+}
+
+func nullHandlingToProto(nullHandling data.NullHandling) NullHandling {
+	switch nullHandling {
+	case data.NullsFirst:
+		return NullHandling_NULLS_FIRST
+	case data.NullsLast:
+		return NullHandling_NULLS_LAST
+	default:
+		return NullHandling_NATIVE
+	}
+}
+
+// toInt32 converts the provided value to int32, saturating at math.MaxInt32
+func toInt32(value int) int32 {
+	if value > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(value)
+}
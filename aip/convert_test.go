@@ -0,0 +1,52 @@
+package aip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestSortRoundTrip(t *testing.T) {
+	sort := data.NewSort(data.OrderBy("name", data.Desc).WithIgnoreCase().NullsLast(), data.OrderByProperty("id").NullsFirst())
+
+	assert.Equal(t, sort, SortFromProto(SortToProto(sort)))
+}
+
+func TestPageableRoundTrip(t *testing.T) {
+	for _, pageable := range []*data.Pageable{
+		data.NewSortedPageable(3, 25, data.SortBy(data.Desc, "name")),
+		data.NewOffsetPageable(17, 10, data.SortByProperties("id")),
+		data.UnpagedWithSort(data.SortByProperties("id")),
+	} {
+		encoded, err := proto.Marshal(PageableToProto(pageable))
+		assert.Nil(t, err)
+		message := &Pageable{}
+		assert.Nil(t, proto.Unmarshal(encoded, message))
+		decoded, err := PageableFromProto(message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, pageable, decoded)
+	}
+}
+
+func TestPageableFromProtoWithInvalidSize(t *testing.T) {
+	_, err := PageableFromProto(&Pageable{Page: 1})
+
+	assert.Equal(t, data.ErrInvalidSize, err)
+}
+
+func TestPageInfoFromPage(t *testing.T) {
+	page, _ := data.NewCappedPage([]string{"a", "b"}, data.NewSortedPageable(1, 2, data.SortByProperties("name")), 100, 100)
+	info := PageInfoFromPage(page, "token")
+
+	assert := assert.New(t)
+	assert.Equal(int32(1), info.GetNumber())
+	assert.Equal(int32(2), info.GetSize())
+	assert.Equal(int32(50), info.GetTotalPages())
+	assert.Equal(int64(100), info.GetTotalElements())
+	assert.True(info.GetTotalIsLowerBound())
+	assert.Equal("name", info.GetSort().GetOrders()[0].GetProperty())
+	assert.Equal("token", info.GetNextPageToken())
+}
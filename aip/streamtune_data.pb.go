@@ -0,0 +1,586 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: streamtune_data.proto
+
+package aip
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Direction is the sorting direction of an order
+type Direction int32
+
+const (
+	Direction_DIRECTION_UNSPECIFIED Direction = 0
+	Direction_ASC                   Direction = 1
+	Direction_DESC                  Direction = 2
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DIRECTION_UNSPECIFIED",
+		1: "ASC",
+		2: "DESC",
+	}
+	Direction_value = map[string]int32{
+		"DIRECTION_UNSPECIFIED": 0,
+		"ASC":                   1,
+		"DESC":                  2,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_streamtune_data_proto_enumTypes[0].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_streamtune_data_proto_enumTypes[0]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_streamtune_data_proto_rawDescGZIP(), []int{0}
+}
+
+// NullHandling hints the data store about the placement of null values
+type NullHandling int32
+
+const (
+	NullHandling_NULL_HANDLING_UNSPECIFIED NullHandling = 0
+	NullHandling_NATIVE                    NullHandling = 1
+	NullHandling_NULLS_FIRST               NullHandling = 2
+	NullHandling_NULLS_LAST                NullHandling = 3
+)
+
+// Enum value maps for NullHandling.
+var (
+	NullHandling_name = map[int32]string{
+		0: "NULL_HANDLING_UNSPECIFIED",
+		1: "NATIVE",
+		2: "NULLS_FIRST",
+		3: "NULLS_LAST",
+	}
+	NullHandling_value = map[string]int32{
+		"NULL_HANDLING_UNSPECIFIED": 0,
+		"NATIVE":                    1,
+		"NULLS_FIRST":               2,
+		"NULLS_LAST":                3,
+	}
+)
+
+func (x NullHandling) Enum() *NullHandling {
+	p := new(NullHandling)
+	*p = x
+	return p
+}
+
+func (x NullHandling) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NullHandling) Descriptor() protoreflect.EnumDescriptor {
+	return file_streamtune_data_proto_enumTypes[1].Descriptor()
+}
+
+func (NullHandling) Type() protoreflect.EnumType {
+	return &file_streamtune_data_proto_enumTypes[1]
+}
+
+func (x NullHandling) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NullHandling.Descriptor instead.
+func (NullHandling) EnumDescriptor() ([]byte, []int) {
+	return file_streamtune_data_proto_rawDescGZIP(), []int{1}
+}
+
+// Order is the pairing of a property and a direction
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Property      string                 `protobuf:"bytes,1,opt,name=property,proto3" json:"property,omitempty"`
+	Direction     Direction              `protobuf:"varint,2,opt,name=direction,proto3,enum=streamtune.data.v1.Direction" json:"direction,omitempty"`
+	IgnoreCase    bool                   `protobuf:"varint,3,opt,name=ignore_case,json=ignoreCase,proto3" json:"ignore_case,omitempty"`
+	NullHandling  NullHandling           `protobuf:"varint,4,opt,name=null_handling,json=nullHandling,proto3,enum=streamtune.data.v1.NullHandling" json:"null_handling,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_streamtune_data_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_streamtune_data_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_streamtune_data_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetProperty() string {
+	if x != nil {
+		return x.Property
+	}
+	return ""
+}
+
+func (x *Order) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+func (x *Order) GetIgnoreCase() bool {
+	if x != nil {
+		return x.IgnoreCase
+	}
+	return false
+}
+
+func (x *Order) GetNullHandling() NullHandling {
+	if x != nil {
+		return x.NullHandling
+	}
+	return NullHandling_NULL_HANDLING_UNSPECIFIED
+}
+
+// Sort holds the orders of a query
+type Sort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sort) Reset() {
+	*x = Sort{}
+	mi := &file_streamtune_data_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sort) ProtoMessage() {}
+
+func (x *Sort) ProtoReflect() protoreflect.Message {
+	mi := &file_streamtune_data_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sort.ProtoReflect.Descriptor instead.
+func (*Sort) Descriptor() ([]byte, []int) {
+	return file_streamtune_data_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Sort) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+// Pageable is a page request
+type Pageable struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size  int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Sort  *Sort                  `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	// offset is set for offset based requests, which may not be aligned to the page size
+	Offset        int64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	OffsetBased   bool  `protobuf:"varint,5,opt,name=offset_based,json=offsetBased,proto3" json:"offset_based,omitempty"`
+	Unpaged       bool  `protobuf:"varint,6,opt,name=unpaged,proto3" json:"unpaged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pageable) Reset() {
+	*x = Pageable{}
+	mi := &file_streamtune_data_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pageable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pageable) ProtoMessage() {}
+
+func (x *Pageable) ProtoReflect() protoreflect.Message {
+	mi := &file_streamtune_data_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pageable.ProtoReflect.Descriptor instead.
+func (*Pageable) Descriptor() ([]byte, []int) {
+	return file_streamtune_data_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Pageable) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Pageable) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Pageable) GetSort() *Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *Pageable) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Pageable) GetOffsetBased() bool {
+	if x != nil {
+		return x.OffsetBased
+	}
+	return false
+}
+
+func (x *Pageable) GetUnpaged() bool {
+	if x != nil {
+		return x.Unpaged
+	}
+	return false
+}
+
+// PageInfo holds the pagination details of a page
+type PageInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Number            int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Size              int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	TotalPages        int32                  `protobuf:"varint,3,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	TotalElements     int64                  `protobuf:"varint,4,opt,name=total_elements,json=totalElements,proto3" json:"total_elements,omitempty"`
+	TotalIsLowerBound bool                   `protobuf:"varint,5,opt,name=total_is_lower_bound,json=totalIsLowerBound,proto3" json:"total_is_lower_bound,omitempty"`
+	TotalIsEstimated  bool                   `protobuf:"varint,6,opt,name=total_is_estimated,json=totalIsEstimated,proto3" json:"total_is_estimated,omitempty"`
+	Sort              *Sort                  `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	NextPageToken     string                 `protobuf:"bytes,8,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_streamtune_data_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_streamtune_data_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_streamtune_data_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PageInfo) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *PageInfo) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotalElements() int64 {
+	if x != nil {
+		return x.TotalElements
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotalIsLowerBound() bool {
+	if x != nil {
+		return x.TotalIsLowerBound
+	}
+	return false
+}
+
+func (x *PageInfo) GetTotalIsEstimated() bool {
+	if x != nil {
+		return x.TotalIsEstimated
+	}
+	return false
+}
+
+func (x *PageInfo) GetSort() *Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *PageInfo) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// PageToken is the content of the opaque page_token handed to the clients
+type PageToken struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// checksum identifies the order_by and filter the token was issued for
+	Checksum      []byte `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageToken) Reset() {
+	*x = PageToken{}
+	mi := &file_streamtune_data_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageToken) ProtoMessage() {}
+
+func (x *PageToken) ProtoReflect() protoreflect.Message {
+	mi := &file_streamtune_data_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageToken.ProtoReflect.Descriptor instead.
+func (*PageToken) Descriptor() ([]byte, []int) {
+	return file_streamtune_data_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PageToken) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PageToken) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+var File_streamtune_data_proto protoreflect.FileDescriptor
+
+const file_streamtune_data_proto_rawDesc = "" +
+	"\n" +
+	"\x15streamtune_data.proto\x12\x12streamtune.data.v1\"\xc8\x01\n" +
+	"\x05Order\x12\x1a\n" +
+	"\bproperty\x18\x01 \x01(\tR\bproperty\x12;\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x1d.streamtune.data.v1.DirectionR\tdirection\x12\x1f\n" +
+	"\vignore_case\x18\x03 \x01(\bR\n" +
+	"ignoreCase\x12E\n" +
+	"\rnull_handling\x18\x04 \x01(\x0e2 .streamtune.data.v1.NullHandlingR\fnullHandling\"9\n" +
+	"\x04Sort\x121\n" +
+	"\x06orders\x18\x01 \x03(\v2\x19.streamtune.data.v1.OrderR\x06orders\"\xb5\x01\n" +
+	"\bPageable\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12,\n" +
+	"\x04sort\x18\x03 \x01(\v2\x18.streamtune.data.v1.SortR\x04sort\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\x12!\n" +
+	"\foffset_based\x18\x05 \x01(\bR\voffsetBased\x12\x18\n" +
+	"\aunpaged\x18\x06 \x01(\bR\aunpaged\"\xb3\x02\n" +
+	"\bPageInfo\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x1f\n" +
+	"\vtotal_pages\x18\x03 \x01(\x05R\n" +
+	"totalPages\x12%\n" +
+	"\x0etotal_elements\x18\x04 \x01(\x03R\rtotalElements\x12/\n" +
+	"\x14total_is_lower_bound\x18\x05 \x01(\bR\x11totalIsLowerBound\x12,\n" +
+	"\x12total_is_estimated\x18\x06 \x01(\bR\x10totalIsEstimated\x12,\n" +
+	"\x04sort\x18\a \x01(\v2\x18.streamtune.data.v1.SortR\x04sort\x12&\n" +
+	"\x0fnext_page_token\x18\b \x01(\tR\rnextPageToken\"?\n" +
+	"\tPageToken\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x1a\n" +
+	"\bchecksum\x18\x02 \x01(\fR\bchecksum*9\n" +
+	"\tDirection\x12\x19\n" +
+	"\x15DIRECTION_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03ASC\x10\x01\x12\b\n" +
+	"\x04DESC\x10\x02*Z\n" +
+	"\fNullHandling\x12\x1d\n" +
+	"\x19NULL_HANDLING_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06NATIVE\x10\x01\x12\x0f\n" +
+	"\vNULLS_FIRST\x10\x02\x12\x0e\n" +
+	"\n" +
+	"NULLS_LAST\x10\x03B!Z\x1fgopkg.in/streamtune/data.v1/aipb\x06proto3"
+
+var (
+	file_streamtune_data_proto_rawDescOnce sync.Once
+	file_streamtune_data_proto_rawDescData []byte
+)
+
+func file_streamtune_data_proto_rawDescGZIP() []byte {
+	file_streamtune_data_proto_rawDescOnce.Do(func() {
+		file_streamtune_data_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_streamtune_data_proto_rawDesc), len(file_streamtune_data_proto_rawDesc)))
+	})
+	return file_streamtune_data_proto_rawDescData
+}
+
+var file_streamtune_data_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_streamtune_data_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_streamtune_data_proto_goTypes = []any{
+	(Direction)(0),    // 0: streamtune.data.v1.Direction
+	(NullHandling)(0), // 1: streamtune.data.v1.NullHandling
+	(*Order)(nil),     // 2: streamtune.data.v1.Order
+	(*Sort)(nil),      // 3: streamtune.data.v1.Sort
+	(*Pageable)(nil),  // 4: streamtune.data.v1.Pageable
+	(*PageInfo)(nil),  // 5: streamtune.data.v1.PageInfo
+	(*PageToken)(nil), // 6: streamtune.data.v1.PageToken
+}
+var file_streamtune_data_proto_depIdxs = []int32{
+	0, // 0: streamtune.data.v1.Order.direction:type_name -> streamtune.data.v1.Direction
+	1, // 1: streamtune.data.v1.Order.null_handling:type_name -> streamtune.data.v1.NullHandling
+	2, // 2: streamtune.data.v1.Sort.orders:type_name -> streamtune.data.v1.Order
+	3, // 3: streamtune.data.v1.Pageable.sort:type_name -> streamtune.data.v1.Sort
+	3, // 4: streamtune.data.v1.PageInfo.sort:type_name -> streamtune.data.v1.Sort
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_streamtune_data_proto_init() }
+func file_streamtune_data_proto_init() {
+	if File_streamtune_data_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_streamtune_data_proto_rawDesc), len(file_streamtune_data_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_streamtune_data_proto_goTypes,
+		DependencyIndexes: file_streamtune_data_proto_depIdxs,
+		EnumInfos:         file_streamtune_data_proto_enumTypes,
+		MessageInfos:      file_streamtune_data_proto_msgTypes,
+	}.Build()
+	File_streamtune_data_proto = out.File
+	file_streamtune_data_proto_goTypes = nil
+	file_streamtune_data_proto_depIdxs = nil
+}
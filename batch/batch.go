@@ -0,0 +1,181 @@
+// Package batch processes every page of a data source, resuming interrupted jobs from their last checkpoint
+package batch
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// ErrUnpaged is returned when an unpaged request is provided, as it cannot be checkpointed
+// ErrCheckpointMismatch is returned when the stored checkpoint was saved for a different size or sort
+var (
+	ErrUnpaged            = errors.New("Invalid request provided: batch processing requires a paged request")
+	ErrCheckpointMismatch = errors.New("Stored checkpoint does not match the requested page size and sort")
+)
+
+// ProcessFunc handles a page of a batch job
+type ProcessFunc func(ctx context.Context, page *data.Page) error
+
+// CursorFetchFunc loads the items following the provided cursor, which is empty for the first page, and returns the
+// cursor of the next page, empty when the page is the last one
+type CursorFetchFunc[T any] func(ctx context.Context, cursor string) ([]T, string, error)
+
+// Progress is the state of a batch job reported after every completed page
+type Progress struct {
+	// PagesDone is the number of completed pages, including the ones of the previous runs
+	PagesDone int64
+	// TotalPages is the number of pages of the source, 0 when unknown
+	TotalPages int
+	// ItemsDone is the number of processed items, including the ones of the previous runs
+	ItemsDone int64
+	// TotalItems is the number of items of the source, 0 when unknown
+	TotalItems int64
+	// Elapsed is the duration of the current run
+	Elapsed time.Duration
+	// ItemsPerSecond is the processing rate of the current run
+	ItemsPerSecond float64
+}
+
+// Options contains the settings of a batch job
+type Options struct {
+	// Job is the name of the checkpoint of the job
+	Job string
+	// Store persists the checkpoints, the job always starts from the beginning when nil
+	Store Store
+	// Progress is called after every completed page when not nil
+	Progress func(progress Progress)
+}
+
+// ForEachPage processes every page of the fetcher from the provided request, or from the page following the last
+// completed one when the job has a checkpoint. The checkpoint is saved after every processed page and cleared once
+// the last page is processed, so that the next run starts from the beginning. Empty pages are not processed.
+func ForEachPage(ctx context.Context, fetcher data.PageFetcher, start *data.Pageable, process ProcessFunc, options Options) error {
+	if start.IsUnpaged() {
+		return ErrUnpaged
+	}
+	checkpoint, err := loadCheckpoint(ctx, options)
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil {
+		last := checkpoint.Pageable()
+		if last.Size != start.Size || !last.SortOrUnsorted().Equal(start.SortOrUnsorted()) {
+			return ErrCheckpointMismatch
+		}
+		start = last.Next()
+	} else {
+		checkpoint = &Checkpoint{}
+	}
+	tracker := newTracker(checkpoint, options)
+	// the checkpoint records the requests, as decoded pages do not know theirs
+	requested := start
+	for page, err := range data.Pages(ctx, fetcher, start) {
+		if err != nil {
+			return err
+		}
+		if !page.HasContent() {
+			break
+		}
+		if err := process(ctx, page); err != nil {
+			return err
+		}
+		checkpoint.setPageable(requested)
+		requested = requested.Next()
+		if err := tracker.completed(ctx, page.NumberOfElements(), page.TotalPages, page.TotalElements); err != nil {
+			return err
+		}
+	}
+	return clearCheckpoint(ctx, options)
+}
+
+// ForEachCursor processes every page of a cursor based source, starting from the cursor of the job checkpoint when
+// present. The checkpoint is saved after every processed page and cleared once the last page is processed.
+func ForEachCursor[T any](ctx context.Context, fetch CursorFetchFunc[T], process func(ctx context.Context, items []T) error, options Options) error {
+	checkpoint, err := loadCheckpoint(ctx, options)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		checkpoint = &Checkpoint{}
+	}
+	tracker := newTracker(checkpoint, options)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		items, next, err := fetch(ctx, checkpoint.Cursor)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return clearCheckpoint(ctx, options)
+		}
+		if err := process(ctx, items); err != nil {
+			return err
+		}
+		checkpoint.Cursor = next
+		if err := tracker.completed(ctx, len(items), 0, 0); err != nil {
+			return err
+		}
+		if next == "" {
+			return clearCheckpoint(ctx, options)
+		}
+	}
+}
+
+// tracker saves the checkpoints and reports the progress of a run
+type tracker struct {
+	checkpoint *Checkpoint
+	options    Options
+	started    time.Time
+	items      int64
+}
+
+func newTracker(checkpoint *Checkpoint, options Options) *tracker {
+	return &tracker{checkpoint: checkpoint, options: options, started: time.Now()}
+}
+
+// completed records a processed page of provided number of items, saving the checkpoint and reporting the progress
+func (t *tracker) completed(ctx context.Context, items int, totalPages int, totalItems int64) error {
+	t.items += int64(items)
+	t.checkpoint.PagesDone++
+	t.checkpoint.ItemsDone += int64(items)
+	t.checkpoint.UpdatedAt = time.Now()
+	if t.options.Store != nil {
+		if err := t.options.Store.Save(ctx, t.options.Job, t.checkpoint); err != nil {
+			return err
+		}
+	}
+	if t.options.Progress != nil {
+		elapsed := time.Since(t.started)
+		progress := Progress{
+			PagesDone:  t.checkpoint.PagesDone,
+			TotalPages: totalPages,
+			ItemsDone:  t.checkpoint.ItemsDone,
+			TotalItems: totalItems,
+			Elapsed:    elapsed,
+		}
+		if elapsed > 0 {
+			progress.ItemsPerSecond = float64(t.items) / elapsed.Seconds()
+		}
+		t.options.Progress(progress)
+	}
+	return nil
+}
+
+func loadCheckpoint(ctx context.Context, options Options) (*Checkpoint, error) {
+	if options.Store == nil {
+		return nil, nil
+	}
+	return options.Store.Load(ctx, options.Job)
+}
+
+func clearCheckpoint(ctx context.Context, options Options) error {
+	if options.Store == nil {
+		return nil
+	}
+	return options.Store.Clear(ctx, options.Job)
+}
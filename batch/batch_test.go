@@ -0,0 +1,149 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+var items = []int{1, 2, 3, 4, 5, 6, 7}
+
+func fetchItems(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+	start := int(pageable.Offset())
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + pageable.Size
+	if end > len(items) {
+		end = len(items)
+	}
+	return data.NewPage(items[start:end], pageable, int64(len(items)))
+}
+
+func TestForEachPageResumesFromCheckpoint(t *testing.T) {
+	store := NewMemoryStore()
+	failure := errors.New("failure")
+	var processed []int
+	failed := false
+	process := func(ctx context.Context, page *data.Page) error {
+		if page.Number == 2 && !failed {
+			failed = true
+			processed = append(processed, -1)
+			return failure
+		}
+		processed = append(processed, page.Content.([]int)...)
+		return nil
+	}
+	options := Options{Job: "job", Store: store}
+	fetcher := data.PageFetcherFunc(fetchItems)
+
+	assert := assert.New(t)
+	assert.Equal(failure, ForEachPage(context.Background(), fetcher, data.NewPageable(0, 2), process, options))
+	checkpoint, _ := store.Load(context.Background(), "job")
+	assert.Equal(1, checkpoint.Page)
+	assert.Equal(int64(2), checkpoint.PagesDone)
+	assert.Equal(int64(4), checkpoint.ItemsDone)
+
+	assert.Nil(ForEachPage(context.Background(), fetcher, data.NewPageable(0, 2), process, options))
+	assert.Equal([]int{1, 2, 3, 4, -1, 5, 6, 7}, processed)
+	checkpoint, _ = store.Load(context.Background(), "job")
+	assert.Nil(checkpoint)
+}
+
+func TestForEachPageWithDecodedPages(t *testing.T) {
+	store := NewMemoryStore()
+	failure := errors.New("failure")
+	fetcher := data.PageFetcherFunc(func(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+		page, err := fetchItems(ctx, pageable)
+		page.Pageable = nil
+		return page, err
+	})
+	process := func(ctx context.Context, page *data.Page) error {
+		if page.Number == 1 {
+			return failure
+		}
+		return nil
+	}
+	options := Options{Job: "job", Store: store}
+
+	assert := assert.New(t)
+	assert.Equal(failure, ForEachPage(context.Background(), fetcher, data.NewPageable(0, 2), process, options))
+	checkpoint, _ := store.Load(context.Background(), "job")
+	assert.Equal(0, checkpoint.Page)
+	assert.Equal(2, checkpoint.Size)
+}
+
+func TestForEachPageReportsProgress(t *testing.T) {
+	var reports []Progress
+	process := func(ctx context.Context, page *data.Page) error { return nil }
+	options := Options{Progress: func(progress Progress) { reports = append(reports, progress) }}
+
+	assert := assert.New(t)
+	assert.Nil(ForEachPage(context.Background(), data.PageFetcherFunc(fetchItems), data.NewPageable(0, 3), process, options))
+	assert.Equal(3, len(reports))
+	assert.Equal(int64(3), reports[2].PagesDone)
+	assert.Equal(3, reports[2].TotalPages)
+	assert.Equal(int64(7), reports[2].ItemsDone)
+	assert.Equal(int64(7), reports[2].TotalItems)
+	assert.True(reports[2].ItemsPerSecond > 0)
+}
+
+func TestForEachPageWithMismatchingCheckpoint(t *testing.T) {
+	store := NewMemoryStore()
+	store.Save(context.Background(), "job", &Checkpoint{Page: 1, Size: 5})
+	process := func(ctx context.Context, page *data.Page) error { return nil }
+
+	err := ForEachPage(context.Background(), data.PageFetcherFunc(fetchItems), data.NewPageable(0, 2), process, Options{Job: "job", Store: store})
+
+	assert.Equal(t, ErrCheckpointMismatch, err)
+}
+
+func TestForEachPageWithOffsetPageable(t *testing.T) {
+	store := NewMemoryStore()
+	store.Save(context.Background(), "job", &Checkpoint{Offset: 1, OffsetBased: true, Size: 3})
+	var processed []int
+	process := func(ctx context.Context, page *data.Page) error {
+		processed = append(processed, page.Content.([]int)...)
+		return nil
+	}
+
+	err := ForEachPage(context.Background(), data.PageFetcherFunc(fetchItems), data.NewOffsetPageable(1, 3, nil), process, Options{Job: "job", Store: store})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{5, 6, 7}, processed)
+}
+
+func TestForEachPageWithUnpagedRequest(t *testing.T) {
+	process := func(ctx context.Context, page *data.Page) error { return nil }
+
+	assert.Equal(t, ErrUnpaged, ForEachPage(context.Background(), data.PageFetcherFunc(fetchItems), data.Unpaged(), process, Options{}))
+}
+
+func TestForEachCursorResumesFromCheckpoint(t *testing.T) {
+	pages := map[string][]string{"": {"a", "b"}, "c1": {"c"}, "c2": {"d"}}
+	cursors := map[string]string{"": "c1", "c1": "c2", "c2": ""}
+	fetch := func(ctx context.Context, cursor string) ([]string, string, error) {
+		return pages[cursor], cursors[cursor], nil
+	}
+	store := NewMemoryStore()
+	store.Save(context.Background(), "job", &Checkpoint{Cursor: "c1", PagesDone: 1, ItemsDone: 2})
+	var processed []string
+	var last Progress
+	options := Options{Job: "job", Store: store, Progress: func(progress Progress) { last = progress }}
+
+	err := ForEachCursor(context.Background(), fetch, func(ctx context.Context, items []string) error {
+		processed = append(processed, items...)
+		return nil
+	}, options)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]string{"c", "d"}, processed)
+	assert.Equal(int64(3), last.PagesDone)
+	assert.Equal(int64(4), last.ItemsDone)
+	checkpoint, _ := store.Load(context.Background(), "job")
+	assert.Nil(checkpoint)
+}
@@ -0,0 +1,153 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// Checkpoint is the position of a batch job after its last completed page
+type Checkpoint struct {
+	// Page, Size, Offset, OffsetBased and Sort describe the last completed request of a paged job
+	Page        int        `json:"page,omitempty"`
+	Size        int        `json:"size,omitempty"`
+	Offset      int64      `json:"offset,omitempty"`
+	OffsetBased bool       `json:"offsetBased,omitempty"`
+	Sort        *data.Sort `json:"sort,omitempty"`
+	// Cursor is the cursor of the page following the last completed one of a cursor based job
+	Cursor    string    `json:"cursor,omitempty"`
+	PagesDone int64     `json:"pagesDone"`
+	ItemsDone int64     `json:"itemsDone"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Pageable returns the last completed request of a paged job
+func (c *Checkpoint) Pageable() *data.Pageable {
+	if c.OffsetBased {
+		return data.NewOffsetPageable(c.Offset, c.Size, c.Sort)
+	}
+	return data.NewSortedPageable(c.Page, c.Size, c.Sort)
+}
+
+func (c *Checkpoint) setPageable(pageable *data.Pageable) {
+	c.Page = pageable.Page
+	c.Size = pageable.Size
+	c.Offset = pageable.Offset()
+	c.OffsetBased = pageable.IsOffsetBased()
+	c.Sort = pageable.SortOrUnsorted()
+}
+
+// Store persists the checkpoints of the batch jobs
+type Store interface {
+	// Load returns the checkpoint of the job, or nil when the job has none
+	Load(ctx context.Context, job string) (*Checkpoint, error)
+	// Save replaces the checkpoint of the job
+	Save(ctx context.Context, job string, checkpoint *Checkpoint) error
+	// Clear removes the checkpoint of the job, if any
+	Clear(ctx context.Context, job string) error
+}
+
+// NewFileStore creates a Store keeping a JSON file for every job in the provided directory, which is created when
+// missing. Files are replaced atomically, so that an interrupted save leaves the previous checkpoint intact.
+func NewFileStore(dir string) Store {
+	return &fileStore{dir: dir}
+}
+
+type fileStore struct {
+	dir string
+}
+
+func (s *fileStore) Load(ctx context.Context, job string) (*Checkpoint, error) {
+	content, err := os.ReadFile(s.path(job))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+func (s *fileStore) Save(ctx context.Context, job string, checkpoint *Checkpoint) error {
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(s.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path(job))
+}
+
+func (s *fileStore) Clear(ctx context.Context, job string) error {
+	err := os.Remove(s.path(job))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *fileStore) path(job string) string {
+	return filepath.Join(s.dir, url.PathEscape(job)+".json")
+}
+
+// NewMemoryStore creates a Store keeping the checkpoints in memory, useful for tests and short lived processes
+func NewMemoryStore() Store {
+	return &memoryStore{checkpoints: map[string]Checkpoint{}}
+}
+
+type memoryStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+func (s *memoryStore) Load(ctx context.Context, job string) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoint, ok := s.checkpoints[job]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, job string, checkpoint *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[job] = *checkpoint
+	return nil
+}
+
+func (s *memoryStore) Clear(ctx context.Context, job string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.checkpoints, job)
+	return nil
+}
@@ -0,0 +1,52 @@
+package batch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "checkpoints")
+	store := NewFileStore(dir)
+	ctx := context.Background()
+	checkpoint := &Checkpoint{Page: 3, Size: 10, Sort: data.SortByProperties("name"), PagesDone: 4, ItemsDone: 40}
+
+	assert := assert.New(t)
+	loaded, err := store.Load(ctx, "nightly/export")
+	assert.Nil(err)
+	assert.Nil(loaded)
+
+	assert.Nil(store.Save(ctx, "nightly/export", checkpoint))
+	loaded, err = store.Load(ctx, "nightly/export")
+	assert.Nil(err)
+	assert.Equal(3, loaded.Page)
+	assert.Equal(int64(40), loaded.ItemsDone)
+	assert.True(loaded.Sort.Equal(data.SortByProperties("name")))
+	entries, _ := os.ReadDir(dir)
+	assert.Equal(1, len(entries))
+
+	assert.Nil(store.Clear(ctx, "nightly/export"))
+	assert.Nil(store.Clear(ctx, "nightly/export"))
+	loaded, err = store.Load(ctx, "nightly/export")
+	assert.Nil(err)
+	assert.Nil(loaded)
+}
+
+func TestCheckpointPageable(t *testing.T) {
+	sort := data.SortByProperties("name")
+	checkpoint := &Checkpoint{}
+	checkpoint.setPageable(data.NewOffsetPageable(15, 10, sort))
+
+	pageable := checkpoint.Pageable()
+
+	assert := assert.New(t)
+	assert.True(pageable.IsOffsetBased())
+	assert.Equal(int64(15), pageable.Offset())
+	assert.Equal(10, pageable.Size)
+	assert.Equal(sort, pageable.Sort)
+}
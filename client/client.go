@@ -0,0 +1,318 @@
+// Package client walks the pages of remote list APIs
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidContent is returned when the content of a response is not a JSON array of the expected items
+// ErrNotRewindable is returned when a request with a body has to be retried but the body cannot be read again
+var (
+	ErrInvalidContent = errors.New("Invalid response content: expected a JSON array of items")
+	ErrNotRewindable  = errors.New("Request body cannot be sent again: GetBody is not set")
+)
+
+// StatusError is returned when a response has an unexpected status code, after the retries
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+// Error returns the description of the unexpected status
+func (e *StatusError) Error() string {
+	return "Unexpected response status: " + e.Status
+}
+
+// Response is a successful response of a list API, with its body already read
+type Response struct {
+	// Request is the request of the page, without the context of the walk
+	Request *http.Request
+	// HTTP is the received response, whose body was already consumed
+	HTTP *http.Response
+	// Body is the content of the response body
+	Body []byte
+	// Count is the number of items decoded from the body
+	Count int
+}
+
+// Page is a decoded page of a list API
+type Page[T any] struct {
+	Content  []T
+	Response *Response
+}
+
+// Navigator computes the request for the page following the received one
+type Navigator interface {
+	// Next returns the request for the next page, or nil when the response is the last page
+	Next(resp *Response) (*http.Request, error)
+}
+
+// NavigatorFunc is an adapter to allow the use of ordinary functions as navigators
+type NavigatorFunc func(resp *Response) (*http.Request, error)
+
+// Next calls f(resp)
+func (f NavigatorFunc) Next(resp *Response) (*http.Request, error) {
+	return f(resp)
+}
+
+// Limiter delays the requests to respect a rate limit. The golang.org/x/time/rate limiters satisfy it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Retry contains the settings for retrying the requests failed with 429 or 5xx status codes
+type Retry struct {
+	// MaxRetries is the number of times a failed request is sent again, no retries when 0
+	MaxRetries int
+	// MinBackoff is the delay before the first retry, doubled on every next one
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, Retry-After included, when greater than 0
+	MaxBackoff time.Duration
+}
+
+// Options contains the settings of a page walk
+type Options struct {
+	// Client sends the requests, http.DefaultClient when nil
+	Client *http.Client
+	// Navigator computes the next requests, only the first page is fetched when nil
+	Navigator Navigator
+	// Content is the dotted path of the items array in the response body, the body itself when empty
+	Content string
+	// Retry is the retry policy of the failed requests
+	Retry Retry
+	// Limiter is waited before every request, attempts included, when not nil
+	Limiter Limiter
+}
+
+// Pages returns an iterator over the pages of a list API, starting from the provided request and following the
+// navigator up to the last page or the first empty one. The iteration stops after yielding the first error.
+func Pages[T any](ctx context.Context, req *http.Request, options Options) iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		for next := req; next != nil; {
+			resp, err := fetch(ctx, next, options)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			content, err := decode[T](resp.Body, options.Content)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			resp.Count = len(content)
+			if !yield(&Page[T]{content, resp}, nil) || len(content) == 0 || options.Navigator == nil {
+				return
+			}
+			if next, err = options.Navigator.Next(resp); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// Items returns an iterator over the items of the pages of a list API, as Pages does. The iteration stops after
+// yielding the first error, with the zero value of T.
+func Items[T any](ctx context.Context, req *http.Request, options Options) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for page, err := range Pages[T](ctx, req, options) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Content {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Every creates a Limiter allowing a request every interval
+func Every(interval time.Duration) Limiter {
+	return &intervalLimiter{interval: interval}
+}
+
+type intervalLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *intervalLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+	return sleep(ctx, at.Sub(now))
+}
+
+// fetch sends the request, retrying it according to the options, and reads the successful response
+func fetch(ctx context.Context, req *http.Request, options Options) (*Response, error) {
+	client := options.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	for attempt := 0; ; attempt++ {
+		if options.Limiter != nil {
+			if err := options.Limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		attemptReq, err := rewind(req.Clone(ctx), attempt)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return &Response{Request: req, HTTP: resp, Body: body}, nil
+		}
+		if !retryable(resp.StatusCode) || attempt >= options.Retry.MaxRetries {
+			return nil, &StatusError{resp.StatusCode, resp.Status, body}
+		}
+		if err := sleep(ctx, options.Retry.delay(attempt, resp.Header.Get("Retry-After"), time.Now())); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewind replaces the body of the request with a fresh copy for the attempts after the first one
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, ErrNotRewindable
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = body
+	return req, nil
+}
+
+// retryable check if a response with the provided status code may succeed when sent again
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// delay returns the wait before the retry following the provided attempt, honoring the Retry-After header
+func (r Retry) delay(attempt int, retryAfter string, now time.Time) time.Duration {
+	delay, ok := parseRetryAfter(retryAfter, now)
+	if !ok {
+		delay = r.MinBackoff
+		for i := 0; i < attempt && (r.MaxBackoff <= 0 || delay < r.MaxBackoff); i++ {
+			delay *= 2
+		}
+	}
+	if r.MaxBackoff > 0 && delay > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+	return delay
+}
+
+// parseRetryAfter reads the Retry-After header value, either a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if at.Before(now) {
+		return 0, true
+	}
+	return at.Sub(now), true
+}
+
+// sleep waits for the provided duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// decode reads the items array at the provided dotted path of the body
+func decode[T any](body []byte, path string) ([]T, error) {
+	raw, err := lookup(body, path)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return []T{}, nil
+	}
+	content := []T{}
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil, ErrInvalidContent
+	}
+	return content, nil
+}
+
+// lookup returns the raw JSON value at the provided dotted path of the body, or nil when missing or null
+func lookup(body []byte, path string) (json.RawMessage, error) {
+	raw := json.RawMessage(body)
+	if path == "" {
+		return orNil(raw), nil
+	}
+	for _, key := range strings.Split(path, ".") {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, ErrInvalidContent
+		}
+		if raw = orNil(object[key]); raw == nil {
+			return nil, nil
+		}
+	}
+	return raw, nil
+}
+
+// orNil returns nil for empty or null JSON values
+func orNil(raw json.RawMessage) json.RawMessage {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return raw
+}
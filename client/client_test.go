@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/streamtune/data.v1/parser"
+)
+
+func TestItemsFollowingPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		assert.Equal(t, "2", req.URL.Query().Get("size"))
+		contents := []string{`["a","b"]`, `["c","d"]`, `["e"]`}
+		w.Write([]byte(`{"content":` + contents[page] + `,"number":` + strconv.Itoa(page) + `,"size":2,"totalPages":3,"totalElements":5}`))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL+"/items?page=0&size=2", nil)
+	var items []string
+	for item, err := range Items[string](context.Background(), req, Options{Navigator: FollowPages(parser.DefaultParams()), Content: "content"}) {
+		assert.Nil(t, err)
+		items = append(items, item)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, items)
+}
+
+func TestPagesRetriesHonoringRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`[1,2,3]`))
+		}
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	pages := 0
+	for page, err := range Pages[int](context.Background(), req, Options{Retry: Retry{MaxRetries: 2, MinBackoff: time.Millisecond}}) {
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, page.Content)
+		assert.Equal(t, 3, page.Response.Count)
+		pages++
+	}
+
+	assert.Equal(t, 1, pages)
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestPagesWithUnexpectedStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("down"))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	var last error
+	for _, err := range Pages[int](context.Background(), req, Options{Retry: Retry{MaxRetries: 1}}) {
+		last = err
+	}
+
+	assert := assert.New(t)
+	assert.IsType(&StatusError{}, last)
+	assert.Equal(http.StatusServiceUnavailable, last.(*StatusError).StatusCode)
+	assert.Equal([]byte("down"), last.(*StatusError).Body)
+	assert.Equal(int32(2), calls)
+}
+
+func TestPagesDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	for _, err := range Pages[int](context.Background(), req, Options{Retry: Retry{MaxRetries: 3}}) {
+		assert.IsType(t, &StatusError{}, err)
+	}
+
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestPagesWithInvalidContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"content":"nope"}`))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	for _, err := range Pages[int](context.Background(), req, Options{Content: "content"}) {
+		assert.Equal(t, ErrInvalidContent, err)
+	}
+}
+
+func TestPagesWithCancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	for _, err := range Pages[int](ctx, req, Options{Retry: Retry{MaxRetries: 1}}) {
+		assert.Equal(t, context.DeadlineExceeded, err)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	limiter := Every(5 * time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, limiter.Wait(context.Background()))
+	}
+
+	assert.True(t, time.Since(start) >= 15*time.Millisecond)
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	retry := Retry{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert := assert.New(t)
+	assert.Equal(100*time.Millisecond, retry.delay(0, "", now))
+	assert.Equal(400*time.Millisecond, retry.delay(2, "", now))
+	assert.Equal(time.Second, retry.delay(10, "", now))
+	assert.Equal(time.Second, retry.delay(0, "120", now))
+	assert.Equal(500*time.Millisecond, Retry{MaxBackoff: time.Second}.delay(0, "Mon, 01 Jan 2024 12:00:00 GMT", now.Add(-500*time.Millisecond)))
+	assert.Equal(100*time.Millisecond, retry.delay(0, "soon", now))
+}
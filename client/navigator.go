@@ -0,0 +1,176 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/streamtune/data.v1/parser"
+)
+
+// LinkHeader is the header holding the web links of a response
+const LinkHeader = "Link"
+
+// FollowLinks creates a Navigator following the Link header with rel="next", resolved against the page URL. Like
+// redirects, links to another host do not carry the credentials of the request.
+func FollowLinks() Navigator {
+	return NavigatorFunc(func(resp *Response) (*http.Request, error) {
+		target, ok := nextLink(resp.HTTP.Header.Values(LinkHeader))
+		if !ok {
+			return nil, nil
+		}
+		next, err := url.Parse(target)
+		if err != nil {
+			return nil, err
+		}
+		return withURL(resp.Request, resp.Request.URL.ResolveReference(next)), nil
+	})
+}
+
+// FollowPages creates a Navigator for APIs returning data.Page or data.Slice JSON bodies, requesting the page
+// following the body number through the page parameter of provided params
+func FollowPages(params parser.Params) Navigator {
+	if params.PageParam == "" {
+		params = parser.DefaultParams()
+	}
+	return NavigatorFunc(func(resp *Response) (*http.Request, error) {
+		var page struct {
+			Number            int   `json:"number"`
+			Size              int   `json:"size"`
+			TotalPages        *int  `json:"totalPages"`
+			TotalIsLowerBound bool  `json:"totalIsLowerBound"`
+			HasNext           *bool `json:"hasNext"`
+		}
+		if err := json.Unmarshal(resp.Body, &page); err != nil {
+			return nil, ErrInvalidContent
+		}
+		switch {
+		case page.HasNext != nil:
+			if !*page.HasNext {
+				return nil, nil
+			}
+		case page.TotalPages == nil:
+			return nil, ErrInvalidContent
+		case page.Number >= *page.TotalPages-1:
+			if !page.TotalIsLowerBound || page.Size <= 0 || resp.Count < page.Size {
+				return nil, nil
+			}
+		}
+		return withQuery(resp.Request, params.PageParam, strconv.Itoa(page.Number+1)), nil
+	})
+}
+
+// FollowCursor creates a Navigator for cursor based APIs, reading the cursor of the next page at the provided
+// dotted path of the body and sending it as the provided query parameter. A missing, null or empty cursor ends the
+// walk.
+func FollowCursor(field, param string) Navigator {
+	return NavigatorFunc(func(resp *Response) (*http.Request, error) {
+		raw, err := lookup(resp.Body, field)
+		if err != nil || raw == nil {
+			return nil, err
+		}
+		var cursor string
+		if err := json.Unmarshal(raw, &cursor); err != nil {
+			return nil, ErrInvalidContent
+		}
+		if cursor == "" {
+			return nil, nil
+		}
+		return withQuery(resp.Request, param, cursor), nil
+	})
+}
+
+// FollowOffsets creates a Navigator for offset based APIs, advancing the offset query parameter by the number of
+// received items. The walk ends on a page shorter than the limit query parameter, when present.
+func FollowOffsets(offsetParam, limitParam string) Navigator {
+	return NavigatorFunc(func(resp *Response) (*http.Request, error) {
+		query := resp.Request.URL.Query()
+		offset := 0
+		if value := query.Get(offsetParam); value != "" {
+			var err error
+			if offset, err = strconv.Atoi(value); err != nil {
+				return nil, err
+			}
+		}
+		if value := query.Get(limitParam); value != "" {
+			limit, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, err
+			}
+			if resp.Count < limit {
+				return nil, nil
+			}
+		}
+		return withQuery(resp.Request, offsetParam, strconv.Itoa(offset+resp.Count)), nil
+	})
+}
+
+// nextLink returns the target of the link with rel="next" among the provided Link header values
+func nextLink(values []string) (string, bool) {
+	for _, value := range values {
+		for {
+			start := strings.IndexByte(value, '<')
+			if start < 0 {
+				break
+			}
+			end := strings.IndexByte(value[start:], '>')
+			if end < 0 {
+				break
+			}
+			target := value[start+1 : start+end]
+			value = value[start+end+1:]
+			params := value
+			if next := strings.IndexByte(value, '<'); next >= 0 {
+				params = strings.TrimRight(strings.TrimSpace(value[:next]), ",")
+			}
+			if hasRel(params, "next") {
+				return target, true
+			}
+		}
+	}
+	return "", false
+}
+
+// hasRel check if the parameters of a link include the provided relation type
+func hasRel(params, rel string) bool {
+	for _, param := range strings.Split(params, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		for _, field := range strings.Fields(value) {
+			if strings.EqualFold(field, rel) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withQuery creates a copy of the request with the provided query parameter replaced
+func withQuery(req *http.Request, key, value string) *http.Request {
+	target := *req.URL
+	query := target.Query()
+	query.Set(key, value)
+	target.RawQuery = query.Encode()
+	return withURL(req, &target)
+}
+
+// sensitiveHeaders are the headers dropped from the requests sent to another host, as net/http does on redirects
+var sensitiveHeaders = []string{"Authorization", "Www-Authenticate", "Cookie", "Cookie2", "Proxy-Authorization"}
+
+// withURL creates a copy of the request for the provided URL, without the credentials when the host changes
+func withURL(req *http.Request, target *url.URL) *http.Request {
+	next := req.Clone(req.Context())
+	next.URL = target
+	next.Host = target.Host
+	if !strings.EqualFold(req.URL.Host, target.Host) {
+		for _, header := range sensitiveHeaders {
+			next.Header.Del(header)
+		}
+	}
+	return next
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/streamtune/data.v1/parser"
+)
+
+func collect(t *testing.T, req *http.Request, options Options) []string {
+	var items []string
+	for item, err := range Items[string](context.Background(), req, options) {
+		assert.Nil(t, err)
+		items = append(items, item)
+	}
+	return items
+}
+
+func TestFollowLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "token", req.Header.Get("Authorization"))
+		switch req.URL.Query().Get("page") {
+		case "":
+			w.Header().Add("Link", `</items?page=2>; rel="next", </items?page=3>; rel="last"`)
+			w.Write([]byte(`["a"]`))
+		case "2":
+			w.Header().Add("Link", `</items>; rel="first"`)
+			w.Header().Add("Link", `</items?page=3>; rel="next last"`)
+			w.Write([]byte(`["b"]`))
+		default:
+			w.Header().Add("Link", `</items>; rel="first"`)
+			w.Write([]byte(`["c"]`))
+		}
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL+"/items", nil)
+	req.Header.Set("Authorization", "token")
+
+	assert.Equal(t, []string{"a", "b", "c"}, collect(t, req, Options{Navigator: FollowLinks()}))
+}
+
+func TestFollowLinksToAnotherHostDropsCredentials(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Empty(t, req.Header.Get("Authorization"))
+		assert.Empty(t, req.Header.Get("Cookie"))
+		assert.Equal(t, "test", req.Header.Get("User-Agent"))
+		w.Write([]byte(`["b"]`))
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "token", req.Header.Get("Authorization"))
+		w.Header().Add("Link", "<"+other.URL+`/items?page=2>; rel="next"`)
+		w.Write([]byte(`["a"]`))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL+"/items", nil)
+	req.Header.Set("Authorization", "token")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("User-Agent", "test")
+
+	assert.Equal(t, []string{"a", "b"}, collect(t, req, Options{Navigator: FollowLinks()}))
+}
+
+func TestFollowPagesWithSlices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("p") == "1" {
+			w.Write([]byte(`{"content":["b"],"number":1,"size":1,"hasNext":false}`))
+			return
+		}
+		w.Write([]byte(`{"content":["a"],"number":0,"size":1,"hasNext":true}`))
+	}))
+	defer server.Close()
+
+	params := parser.DefaultParams()
+	params.PageParam = "p"
+	req, _ := http.NewRequest("GET", server.URL, nil)
+
+	assert.Equal(t, []string{"a", "b"}, collect(t, req, Options{Navigator: FollowPages(params), Content: "content"}))
+}
+
+func TestFollowCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("after") {
+		case "":
+			w.Write([]byte(`{"data":{"items":["a","b"]},"meta":{"next":"c1"}}`))
+		case "c1":
+			w.Write([]byte(`{"data":{"items":["c"]},"meta":{"next":"c2"}}`))
+		default:
+			w.Write([]byte(`{"data":{"items":["d"]},"meta":{"next":null}}`))
+		}
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL+"?filter=x", nil)
+	options := Options{Navigator: FollowCursor("meta.next", "after"), Content: "data.items"}
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, collect(t, req, options))
+}
+
+func TestFollowOffsets(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		body := "["
+		for i, item := range items[offset:end] {
+			if i > 0 {
+				body += ","
+			}
+			body += strconv.Quote(item)
+		}
+		w.Write([]byte(body + "]"))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL+"?limit=2", nil)
+
+	assert.Equal(t, items, collect(t, req, Options{Navigator: FollowOffsets("offset", "limit")}))
+}
+
+func TestNextLink(t *testing.T) {
+	assert := assert.New(t)
+	link, ok := nextLink([]string{`<https://example.com/?a=1,2>; rel=next; title="x"`})
+	assert.True(ok)
+	assert.Equal("https://example.com/?a=1,2", link)
+	_, ok = nextLink([]string{`<https://example.com/>; rel="prev"`, "invalid"})
+	assert.False(ok)
+}
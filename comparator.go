@@ -0,0 +1,484 @@
+package data
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnknownProperty is returned when a sort property does not match any field of the compared type
+// ErrIncomparableProperty is returned when a sort property matches a field whose values cannot be ordered
+var (
+	ErrUnknownProperty      = errors.New("Unknown sort property: no field of the compared type matches it")
+	ErrIncomparableProperty = errors.New("Incomparable sort property: its values cannot be ordered")
+)
+
+// PropertyComparator compares two non nil values of a property, returning a negative number when a is lower than
+// b, a positive number when a is greater than b and 0 otherwise
+type PropertyComparator func(a, b interface{}) int
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Comparator creates a function comparing two items according to the Sort orders. Properties are dotted paths or
+// JSON Pointers of struct fields, matched by json tag, bson tag or case insensitive name, through pointers and
+// embedded structs, and of map keys, slice indexes and json.RawMessage documents. Nil pointers and interfaces, JSON
+// nulls and missing keys are null values, ordered as the lowest values by the Native null handling. Sorting many
+// items holding json.RawMessage documents is faster with a KeyComparator, decoding them once.
+func Comparator[T any](sort *Sort) (func(a, b T) int, error) {
+	return ComparatorWith[T](sort, nil)
+}
+
+// ComparatorWith creates a function comparing two items according to the Sort orders, as Comparator does, using
+// the provided comparators for the values of the matching properties
+func ComparatorWith[T any](sort *Sort, comparators map[string]PropertyComparator) (func(a, b T) int, error) {
+	properties, err := newProperties[T](sort, comparators)
+	if err != nil {
+		return nil, err
+	}
+	return func(a, b T) int {
+		x, y := reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem()
+		for _, property := range properties {
+			if result := property.compare(x, y); result != 0 {
+				return result
+			}
+		}
+		return 0
+	}, nil
+}
+
+// SortKey holds the values of the sort properties of an item, resolved once to be compared many times
+type SortKey struct {
+	values  []reflect.Value
+	present []bool
+}
+
+// KeyComparator compares the items through their sort keys. A Comparator resolves the properties of both items on
+// every comparison, decoding their json.RawMessage documents each time, while the keys resolve them once per item.
+type KeyComparator[T any] struct {
+	properties []*property
+}
+
+// NewKeyComparator creates a KeyComparator for the Sort orders, resolving the properties as ComparatorWith does
+func NewKeyComparator[T any](sort *Sort, comparators map[string]PropertyComparator) (*KeyComparator[T], error) {
+	properties, err := newProperties[T](sort, comparators)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyComparator[T]{properties: properties}, nil
+}
+
+// Key resolves the values of the sort properties of the item
+func (c *KeyComparator[T]) Key(item T) SortKey {
+	value := reflect.ValueOf(&item).Elem()
+	key := SortKey{values: make([]reflect.Value, len(c.properties)), present: make([]bool, len(c.properties))}
+	for i, property := range c.properties {
+		key.values[i], key.present[i] = property.value(value)
+	}
+	return key
+}
+
+// Compare compares two keys created by the KeyComparator, as the Comparator of the same Sort compares their items
+func (c *KeyComparator[T]) Compare(a, b SortKey) int {
+	for i, property := range c.properties {
+		if result := property.compareResolved(a.values[i], a.present[i], b.values[i], b.present[i]); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// Sort sorts the items keeping the order of the equal ones, resolving the key of every item once
+func (c *KeyComparator[T]) Sort(items []T) {
+	type keyed struct {
+		item T
+		key  SortKey
+	}
+	sorted := make([]keyed, len(items))
+	for i, item := range items {
+		sorted[i] = keyed{item, c.Key(item)}
+	}
+	slices.SortStableFunc(sorted, func(a, b keyed) int {
+		return c.Compare(a.key, b.key)
+	})
+	for i := range sorted {
+		items[i] = sorted[i].item
+	}
+}
+
+// property is a sort order resolved against the compared type
+type property struct {
+	order Order
+	steps []step
+	leaf  func(a, b reflect.Value) int
+}
+
+// newProperties resolves the Sort orders against the type of the compared items
+func newProperties[T any](sort *Sort, comparators map[string]PropertyComparator) ([]*property, error) {
+	itemType := reflect.TypeOf((*T)(nil)).Elem()
+	properties := make([]*property, 0, len(sort.orders()))
+	for _, order := range sort.orders() {
+		property, err := newProperty(itemType, order, comparators[order.Property])
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, property)
+	}
+	return properties, nil
+}
+
+// step resolves a segment of a property path, through the field indexes known from the compared type or through
+// a key looked up at runtime into maps, slices, JSON documents and dynamically typed values
+type step struct {
+	index []int
+	key   string
+}
+
+func newProperty(itemType reflect.Type, order Order, comparator PropertyComparator) (*property, error) {
+	segments, ok := propertyPath(order.Property)
+	if !ok {
+		return nil, ErrUnknownProperty
+	}
+	p := &property{order: order}
+	current, dynamic := itemType, false
+	for _, name := range segments {
+		if !dynamic {
+			current = indirectType(current)
+			switch {
+			case isDynamic(current):
+				dynamic = true
+			case current.Kind() == reflect.Struct && current != timeType:
+				index, ok := findField(current, name)
+				if !ok {
+					return nil, ErrUnknownProperty
+				}
+				p.steps = append(p.steps, step{index: index})
+				current = current.FieldByIndex(index).Type
+				continue
+			default:
+				return nil, ErrUnknownProperty
+			}
+		}
+		p.steps = append(p.steps, step{key: name})
+	}
+	p.leaf = p.compareValues
+	if comparator != nil {
+		p.leaf = func(a, b reflect.Value) int {
+			return comparator(a.Interface(), b.Interface())
+		}
+	} else if !dynamic && !isComparable(indirectType(current)) {
+		return nil, ErrIncomparableProperty
+	}
+	return p, nil
+}
+
+// compare compares the property of two items
+func (p *property) compare(a, b reflect.Value) int {
+	x, okX := p.value(a)
+	y, okY := p.value(b)
+	return p.compareResolved(x, okX, y, okY)
+}
+
+// compareResolved compares two values of the property, which are null when not ok
+func (p *property) compareResolved(x reflect.Value, okX bool, y reflect.Value, okY bool) int {
+	if okX && okY {
+		return p.direct(p.leaf(x, y))
+	}
+	return p.nulls(okX, okY)
+}
+
+// propertyPath splits a property into the segments of its path, which is either a JSON Pointer or a dotted path
+func propertyPath(property string) ([]string, bool) {
+	if property == "" {
+		return nil, false
+	}
+	if !strings.HasPrefix(property, "/") {
+		return strings.Split(property, "."), true
+	}
+	segments := strings.Split(property[1:], "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+	}
+	return segments, true
+}
+
+// value returns the value of the property for the item, or false when it's null or missing
+func (p *property) value(item reflect.Value) (reflect.Value, bool) {
+	current := item
+	for _, step := range p.steps {
+		var ok bool
+		if step.index != nil {
+			current, ok = fieldByIndex(current, step.index)
+		} else {
+			current, ok = lookupKey(current, step.key)
+		}
+		if !ok {
+			return reflect.Value{}, false
+		}
+	}
+	return resolve(current)
+}
+
+// direct applies the order direction to the result of an ascending comparison
+func (p *property) direct(result int) int {
+	if p.order.IsDescending() {
+		return -result
+	}
+	return result
+}
+
+// nulls compares two values of which at least one is null, according to the order null handling
+func (p *property) nulls(okX, okY bool) int {
+	result := 0
+	switch {
+	case !okX && okY:
+		result = -1
+	case okX && !okY:
+		result = 1
+	}
+	switch p.order.NullHandling {
+	case NullsFirst:
+		return result
+	case NullsLast:
+		return -result
+	default:
+		return p.direct(result)
+	}
+}
+
+// compareValues compares two non null values in ascending order
+func (p *property) compareValues(a, b reflect.Value) int {
+	return compareValues(a, b, p.order.IgnoreCase)
+}
+
+// compareValues compares two non null values in ascending order. Values of different kinds are ordered as booleans,
+// numbers, strings, times and objects, which include maps, slices and structs.
+func compareValues(a, b reflect.Value, ignoreCase bool) int {
+	rankA, rankB := rank(a), rank(b)
+	if rankA != rankB {
+		return cmp.Compare(rankA, rankB)
+	}
+	switch rankA {
+	case rankBool:
+		return cmp.Compare(boolInt(a.Bool()), boolInt(b.Bool()))
+	case rankNumber:
+		return compareNumbers(a, b)
+	case rankString:
+		if ignoreCase {
+			return strings.Compare(strings.ToLower(a.String()), strings.ToLower(b.String()))
+		}
+		return strings.Compare(a.String(), b.String())
+	case rankTime:
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+	return 0
+}
+
+// compareNumbers compares two numbers of any kind
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case isInt(a) && isInt(b):
+		return cmp.Compare(a.Int(), b.Int())
+	case isUint(a) && isUint(b):
+		return cmp.Compare(a.Uint(), b.Uint())
+	case isInt(a) && isUint(b):
+		if a.Int() < 0 {
+			return -1
+		}
+		return cmp.Compare(uint64(a.Int()), b.Uint())
+	case isUint(a) && isInt(b):
+		return -compareNumbers(b, a)
+	}
+	return cmp.Compare(toFloat(a), toFloat(b))
+}
+
+// rankBool, rankNumber, rankString, rankTime and rankObject order the values of different kinds
+const (
+	rankBool = iota + 1
+	rankNumber
+	rankString
+	rankTime
+	rankObject
+)
+
+func rank(value reflect.Value) int {
+	switch {
+	case value.Kind() == reflect.Bool:
+		return rankBool
+	case isInt(value) || isUint(value) || value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		return rankNumber
+	case value.Kind() == reflect.String:
+		return rankString
+	case value.Type() == timeType:
+		return rankTime
+	}
+	return rankObject
+}
+
+// isComparable check if the values of provided type can be ordered, which is unknown until runtime for interfaces
+func isComparable(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Bool, reflect.String, reflect.Interface, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return valueType == timeType || valueType == rawMessageType
+}
+
+// isDynamic check if the values of provided type can only be navigated at runtime
+func isDynamic(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Interface, reflect.Slice, reflect.Array:
+		return true
+	case reflect.Map:
+		return valueType.Key().Kind() == reflect.String
+	}
+	return false
+}
+
+func isInt(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func toFloat(value reflect.Value) float64 {
+	switch {
+	case isInt(value):
+		return float64(value.Int())
+	case isUint(value):
+		return float64(value.Uint())
+	}
+	return value.Float()
+}
+
+func boolInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// findField returns the index sequence of the exported field matching the name, looking into embedded structs
+// when no direct field matches
+func findField(structType reflect.Type, name string) ([]int, bool) {
+	var embedded [][]int
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if matchesField(field, name) {
+			return []int{i}, true
+		}
+		if fieldType := indirectType(field.Type); field.Anonymous && fieldType.Kind() == reflect.Struct {
+			embedded = append(embedded, []int{i})
+		}
+	}
+	for _, index := range embedded {
+		if nested, ok := findField(indirectType(structType.Field(index[0]).Type), name); ok {
+			return append(index, nested...), true
+		}
+	}
+	return nil, false
+}
+
+// matchesField check if the name is the json or bson name of the field, or its case insensitive Go name
+func matchesField(field reflect.StructField, name string) bool {
+	for _, key := range []string{"json", "bson"} {
+		tag, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if tag == name && tag != "-" {
+			return true
+		}
+	}
+	return strings.EqualFold(field.Name, name)
+}
+
+// fieldByIndex returns the nested field of a struct value, or false when a pointer in between is nil
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		var ok bool
+		if value, ok = indirect(value); !ok || value.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		value = value.Field(i)
+	}
+	return value, true
+}
+
+// lookupKey returns the element of a map, slice, JSON document or struct value for the key, or false when missing
+func lookupKey(value reflect.Value, key string) (reflect.Value, bool) {
+	value, ok := resolve(value)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		element := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+		return element, element.IsValid()
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= value.Len() {
+			return reflect.Value{}, false
+		}
+		return value.Index(i), true
+	case reflect.Struct:
+		if index, ok := findField(value.Type(), key); ok && value.Type() != timeType {
+			return fieldByIndex(value, index)
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// resolve returns the value held by pointers and interfaces, decoding JSON documents, or false when null
+func resolve(value reflect.Value) (reflect.Value, bool) {
+	value, ok := indirect(value)
+	if !ok || value.Type() != rawMessageType {
+		return value, ok
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(value.Bytes(), &decoded); err != nil {
+		return reflect.Value{}, false
+	}
+	return indirect(reflect.ValueOf(&decoded).Elem())
+}
+
+// indirectType returns the type pointed by the provided one, following every pointer
+func indirectType(valueType reflect.Type) reflect.Type {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+	return valueType
+}
+
+// indirect returns the value held by pointers and interfaces, or false when nil
+func indirect(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, false
+		}
+		value = value.Elem()
+	}
+	return value, value.IsValid()
+}
@@ -0,0 +1,255 @@
+package data
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Audit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type address struct {
+	City string `bson:"city_name"`
+}
+
+type person struct {
+	Audit
+	Name    string   `json:"name"`
+	Age     *int     `json:"age,omitempty"`
+	Score   float64  `json:"-"`
+	Address *address `json:"address"`
+	Tags    []string `json:"tags"`
+	Extra   interface{}
+	secret  string
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func names(people []person) []string {
+	result := make([]string, len(people))
+	for i, p := range people {
+		result[i] = p.Name
+	}
+	return result
+}
+
+func sortPeople(t *testing.T, people []person, by *Sort) []string {
+	compare, err := Comparator[person](by)
+	assert.Nil(t, err)
+	sort.SliceStable(people, func(i, j int) bool { return compare(people[i], people[j]) < 0 })
+	return names(people)
+}
+
+func TestComparatorWithMultipleOrders(t *testing.T) {
+	people := []person{
+		{Name: "carl", Age: intPointer(30)},
+		{Name: "anna", Age: intPointer(40)},
+		{Name: "bob", Age: intPointer(30)},
+	}
+
+	assert.Equal(t, []string{"carl", "bob", "anna"}, sortPeople(t, people, NewSort(OrderBy("age", Asc), OrderBy("name", Desc))))
+}
+
+func TestComparatorWithNestedAndEmbeddedFields(t *testing.T) {
+	now := time.Now()
+	people := []person{
+		{Name: "carl", Address: &address{"Rome"}, Audit: Audit{now}},
+		{Name: "anna", Address: &address{"Milan"}, Audit: Audit{now.Add(time.Hour)}},
+		{Name: "bob", Address: &address{"Bari"}, Audit: Audit{now.Add(-time.Hour)}},
+	}
+
+	assert.Equal(t, []string{"bob", "anna", "carl"}, sortPeople(t, people, SortByProperties("address.city_name")))
+	assert.Equal(t, []string{"anna", "carl", "bob"}, sortPeople(t, people, SortBy(Desc, "created_at")))
+	assert.Equal(t, []string{"bob", "carl", "anna"}, sortPeople(t, people, SortByProperties("Audit.CreatedAt")))
+}
+
+func TestComparatorWithNullHandling(t *testing.T) {
+	people := func() []person {
+		return []person{{Name: "a", Age: intPointer(2)}, {Name: "b"}, {Name: "c", Age: intPointer(1)}}
+	}
+
+	assert := assert.New(t)
+	assert.Equal([]string{"b", "c", "a"}, sortPeople(t, people(), SortByProperties("age")))
+	assert.Equal([]string{"a", "c", "b"}, sortPeople(t, people(), SortBy(Desc, "age")))
+	assert.Equal([]string{"b", "a", "c"}, sortPeople(t, people(), NewSort(OrderBy("age", Desc).NullsFirst())))
+	assert.Equal([]string{"c", "a", "b"}, sortPeople(t, people(), NewSort(OrderBy("age", Asc).NullsLast())))
+	assert.Equal([]string{"c", "a", "b"}, sortPeople(t, []person{{Name: "a", Address: &address{"x"}}, {Name: "b"}, {Name: "c", Address: &address{"a"}}}, NewSort(OrderByProperty("address.city").NullsLast())))
+}
+
+func TestComparatorWithIgnoreCase(t *testing.T) {
+	people := func() []person { return []person{{Name: "b"}, {Name: "C"}, {Name: "a"}} }
+
+	assert.Equal(t, []string{"C", "a", "b"}, sortPeople(t, people(), SortByProperties("name")))
+	assert.Equal(t, []string{"a", "b", "C"}, sortPeople(t, people(), NewSort(OrderByProperty("name").WithIgnoreCase())))
+}
+
+func TestComparatorWithInterfaceValues(t *testing.T) {
+	people := []person{{Name: "a", Extra: "x"}, {Name: "b", Extra: 2.5}, {Name: "c", Extra: uint8(1)}, {Name: "d", Extra: true}, {Name: "e"}}
+
+	assert.Equal(t, []string{"e", "d", "c", "b", "a"}, sortPeople(t, people, SortByProperties("extra")))
+}
+
+func TestComparatorWithPointerItems(t *testing.T) {
+	compare, err := Comparator[*person](SortByProperties("name"))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.True(compare(&person{Name: "a"}, &person{Name: "b"}) < 0)
+	assert.True(compare(nil, &person{Name: "b"}) < 0)
+	assert.Equal(0, compare(nil, nil))
+}
+
+func TestComparatorWithCustomComparator(t *testing.T) {
+	byLength := func(a, b interface{}) int {
+		return len(a.([]string)) - len(b.([]string))
+	}
+	compare, err := ComparatorWith[person](SortBy(Desc, "tags"), map[string]PropertyComparator{"tags": byLength})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.True(compare(person{Tags: []string{"a", "b"}}, person{Tags: []string{"c"}}) < 0)
+}
+
+func TestComparatorWithInvalidProperties(t *testing.T) {
+	assert := assert.New(t)
+	for _, property := range []string{"unknown", "secret", "score.value", "name.first", "Score"} {
+		_, err := Comparator[person](SortByProperties(property))
+		if property == "Score" {
+			assert.Nil(err)
+			continue
+		}
+		assert.Equal(ErrUnknownProperty, err, property)
+	}
+	_, err := Comparator[person](SortByProperties("tags"))
+	assert.Equal(ErrIncomparableProperty, err)
+	_, err = Comparator[string](SortByProperties("name"))
+	assert.Equal(ErrUnknownProperty, err)
+}
+
+func TestComparatorWithoutOrders(t *testing.T) {
+	compare, err := Comparator[person](nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, compare(person{Name: "a"}, person{Name: "b"}))
+}
+
+func TestComparatorWithMaps(t *testing.T) {
+	documents := []map[string]interface{}{
+		{"id": "a", "user": map[string]interface{}{"name": "zoe"}},
+		{"id": "b", "user": map[string]interface{}{"name": nil}},
+		{"id": "c", "user": map[string]interface{}{"name": "adam"}},
+		{"id": "d"},
+	}
+	compare, err := Comparator[map[string]interface{}](NewSort(OrderByProperty("user.name").NullsLast()))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	sort.SliceStable(documents, func(i, j int) bool { return compare(documents[i], documents[j]) < 0 })
+	var ids []interface{}
+	for _, document := range documents {
+		ids = append(ids, document["id"])
+	}
+	assert.Equal([]interface{}{"c", "a", "b", "d"}, ids)
+}
+
+func TestComparatorWithJSONPointers(t *testing.T) {
+	documents := []json.RawMessage{
+		json.RawMessage(`{"a/b":{"items":[{"v":"text"}]}}`),
+		json.RawMessage(`{"a/b":{"items":[{"v":{"x":1}}]}}`),
+		json.RawMessage(`{"a/b":{"items":[{"v":3}]}}`),
+		json.RawMessage(`{"a/b":{"items":[{"v":true}]}}`),
+		json.RawMessage(`{"a/b":{"items":[{"v":null}]}}`),
+		json.RawMessage(`{"a/b":{"items":[{"v":-2.5}]}}`),
+		json.RawMessage(`{"a/b":{"items":[]}}`),
+	}
+	compare, err := Comparator[json.RawMessage](SortByProperties("/a~1b/items/0/v"))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	sort.SliceStable(documents, func(i, j int) bool { return compare(documents[i], documents[j]) < 0 })
+	var values []string
+	for _, document := range documents {
+		values = append(values, string(document))
+	}
+	assert.Equal([]string{
+		`{"a/b":{"items":[{"v":null}]}}`,
+		`{"a/b":{"items":[]}}`,
+		`{"a/b":{"items":[{"v":true}]}}`,
+		`{"a/b":{"items":[{"v":-2.5}]}}`,
+		`{"a/b":{"items":[{"v":3}]}}`,
+		`{"a/b":{"items":[{"v":"text"}]}}`,
+		`{"a/b":{"items":[{"v":{"x":1}}]}}`,
+	}, values)
+}
+
+func TestKeyComparatorSortsDocuments(t *testing.T) {
+	documents := []json.RawMessage{
+		json.RawMessage(`{"id":1,"user":{"name":"bob"},"score":3}`),
+		json.RawMessage(`{"id":2,"user":{"name":"Alice"},"score":3}`),
+		json.RawMessage(`{"id":3,"score":7}`),
+		json.RawMessage(`{"id":4,"user":{"name":"carol"},"score":3}`),
+		json.RawMessage(`{"id":5,"user":{"name":"alice"},"score":7}`),
+	}
+	by := NewSort(OrderBy("score", Desc), OrderByProperty("user.name").WithIgnoreCase().NullsLast())
+	keys, err := NewKeyComparator[json.RawMessage](by, nil)
+	compare, _ := Comparator[json.RawMessage](by)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	for _, a := range documents {
+		for _, b := range documents {
+			assert.Equal(compare(a, b), keys.Compare(keys.Key(a), keys.Key(b)))
+		}
+	}
+	keys.Sort(documents)
+	var ids []int
+	for _, document := range documents {
+		var decoded struct{ ID int }
+		json.Unmarshal(document, &decoded)
+		ids = append(ids, decoded.ID)
+	}
+	assert.Equal([]int{5, 3, 2, 1, 4}, ids)
+	_, err = NewKeyComparator[json.RawMessage](SortByProperties(""), nil)
+	assert.Equal(ErrUnknownProperty, err)
+}
+
+func TestComparatorWithDocumentFields(t *testing.T) {
+	type webhook struct {
+		ID      int             `json:"id"`
+		Payload json.RawMessage `json:"payload"`
+		Headers map[string]any  `json:"headers"`
+		Events  []any           `json:"events"`
+	}
+	hooks := []webhook{
+		{ID: 1, Payload: json.RawMessage(`{"amount":20}`), Headers: map[string]any{"X-Seq": 2}, Events: []any{"b"}},
+		{ID: 2, Payload: json.RawMessage(`{"amount":5}`), Headers: map[string]any{"X-Seq": 1}, Events: []any{"c"}},
+		{ID: 3, Payload: json.RawMessage(`invalid`), Events: []any{"a"}},
+	}
+	ids := func(by *Sort) []int {
+		compare, err := Comparator[webhook](by)
+		assert.Nil(t, err)
+		sorted := append([]webhook(nil), hooks...)
+		sort.SliceStable(sorted, func(i, j int) bool { return compare(sorted[i], sorted[j]) < 0 })
+		result := make([]int, len(sorted))
+		for i, hook := range sorted {
+			result[i] = hook.ID
+		}
+		return result
+	}
+
+	assert := assert.New(t)
+	assert.Equal([]int{3, 2, 1}, ids(SortByProperties("payload.amount")))
+	assert.Equal([]int{1, 2, 3}, ids(NewSort(OrderBy("headers.X-Seq", Desc).NullsLast())))
+	assert.Equal([]int{3, 1, 2}, ids(SortByProperties("/events/0")))
+	_, err := Comparator[webhook](SortByProperties("payload"))
+	assert.Nil(err)
+	_, err = Comparator[webhook](SortByProperties("id.value"))
+	assert.Equal(ErrUnknownProperty, err)
+}
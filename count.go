@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"reflect"
+)
+
+// Counter is implemented by the data store adapters to count the elements matched by a query
+type Counter interface {
+	// Count returns the exact number of elements
+	Count(ctx context.Context) (int64, error)
+	// CountUpTo returns the number of elements, stopping when the limit is reached
+	CountUpTo(ctx context.Context, limit int64) (int64, error)
+	// EstimatedCount returns a cheap approximation of the number of elements
+	EstimatedCount(ctx context.Context) (int64, error)
+}
+
+// CountStrategy decides how the total number of elements is computed when building a page from its content
+type CountStrategy interface {
+	NewPage(ctx context.Context, content interface{}, pageable *Pageable, counter Counter) (*Page, error)
+}
+
+// CountStrategyFunc is an adapter to allow the use of ordinary functions as count strategies
+type CountStrategyFunc func(ctx context.Context, content interface{}, pageable *Pageable, counter Counter) (*Page, error)
+
+// NewPage calls f(ctx, content, pageable, counter)
+func (f CountStrategyFunc) NewPage(ctx context.Context, content interface{}, pageable *Pageable, counter Counter) (*Page, error) {
+	return f(ctx, content, pageable, counter)
+}
+
+// ExactCount returns the strategy counting every element. The count is skipped when the total can be derived
+// from the content, as for a first page not filled up.
+func ExactCount() CountStrategy {
+	return CountStrategyFunc(func(ctx context.Context, content interface{}, pageable *Pageable, counter Counter) (*Page, error) {
+		if total, ok := knownTotal(content, pageable); ok {
+			return NewPage(content, pageable, total)
+		}
+		total, err := counter.Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return NewPage(content, pageable, total)
+	})
+}
+
+// EstimatedCount returns the strategy using the data store estimation of the number of elements. The estimation is
+// raised when lower than the elements preceding the page and the ones it holds.
+func EstimatedCount() CountStrategy {
+	return CountStrategyFunc(func(ctx context.Context, content interface{}, pageable *Pageable, counter Counter) (*Page, error) {
+		if total, ok := knownTotal(content, pageable); ok {
+			return NewPage(content, pageable, total)
+		}
+		total, err := counter.EstimatedCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if seen := pageable.Offset() + int64(lengthOf(content)); total < seen {
+			total = seen
+		}
+		page, err := NewPage(content, pageable, total)
+		if err != nil {
+			return nil, err
+		}
+		page.TotalIsEstimated = true
+		return page, nil
+	})
+}
+
+// CappedCount returns the strategy counting elements up to the provided limit. When the limit is reached the
+// total is flagged as a lower bound.
+func CappedCount(limit int64) CountStrategy {
+	return CountStrategyFunc(func(ctx context.Context, content interface{}, pageable *Pageable, counter Counter) (*Page, error) {
+		if total, ok := knownTotal(content, pageable); ok {
+			return NewPage(content, pageable, total)
+		}
+		total, err := counter.CountUpTo(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+		return NewCappedPage(content, pageable, total, limit)
+	})
+}
+
+// AsyncCount returns the strategy building the page without waiting for the count, whose result is delivered
+// later to the provided callback from another goroutine. Until then the page total is a lower bound made of the
+// elements preceding the page and the ones it holds. The count is not cancelled with the provided context, since
+// it's expected to outlive the request. The callback is invoked right away when the total can be derived from
+// the content.
+func AsyncCount(callback func(total int64, err error)) CountStrategy {
+	return CountStrategyFunc(func(ctx context.Context, content interface{}, pageable *Pageable, counter Counter) (*Page, error) {
+		if total, ok := knownTotal(content, pageable); ok {
+			page, err := NewPage(content, pageable, total)
+			if err == nil {
+				callback(total, nil)
+			}
+			return page, err
+		}
+		page, err := NewLowerBoundPage(content, pageable, pageable.Offset()+int64(lengthOf(content)))
+		if err != nil {
+			return nil, err
+		}
+		go func(ctx context.Context) {
+			callback(counter.Count(ctx))
+		}(context.WithoutCancel(ctx))
+		return page, nil
+	})
+}
+
+// knownTotal returns the total number of elements when it can be derived from the page content, that's when the
+// request is unpaged or the page is not filled up but not empty
+func knownTotal(content interface{}, pageable *Pageable) (int64, bool) {
+	length := lengthOf(content)
+	if pageable.IsUnpaged() {
+		return int64(length), true
+	}
+	if length < pageable.Size && (length > 0 || pageable.Offset() == 0) {
+		return pageable.Offset() + int64(length), true
+	}
+	return 0, false
+}
+
+// lengthOf returns the number of elements of an array or slice content, and 0 for any other value
+func lengthOf(content interface{}) int {
+	if validateContent(content) != nil {
+		return 0
+	}
+	return reflect.ValueOf(content).Len()
+}
@@ -0,0 +1,140 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCounter struct {
+	total, estimate int64
+	calls           []string
+}
+
+func (c *fakeCounter) Count(ctx context.Context) (int64, error) {
+	c.calls = append(c.calls, "count")
+	return c.total, nil
+}
+
+func (c *fakeCounter) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	c.calls = append(c.calls, "countUpTo")
+	if c.total > limit {
+		return limit, nil
+	}
+	return c.total, nil
+}
+
+func (c *fakeCounter) EstimatedCount(ctx context.Context) (int64, error) {
+	c.calls = append(c.calls, "estimatedCount")
+	return c.estimate, nil
+}
+
+func TestExactCount(t *testing.T) {
+	counter := &fakeCounter{total: 25}
+	page, err := ExactCount().NewPage(context.Background(), []int{1, 2, 3}, NewPageable(1, 3), counter)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(25), page.TotalElements)
+	assert.Equal([]string{"count"}, counter.calls)
+}
+
+func TestExactCountSkippedForPartialPage(t *testing.T) {
+	counter := &fakeCounter{total: 25}
+	page, err := ExactCount().NewPage(context.Background(), []int{1}, NewPageable(2, 3), counter)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(7), page.TotalElements)
+	assert.Empty(counter.calls)
+}
+
+func TestExactCountSkippedForUnpaged(t *testing.T) {
+	counter := &fakeCounter{total: 25}
+	page, err := ExactCount().NewPage(context.Background(), []int{1, 2, 3, 4}, Unpaged(), counter)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(4), page.TotalElements)
+	assert.Empty(counter.calls)
+}
+
+func TestEstimatedCount(t *testing.T) {
+	counter := &fakeCounter{total: 25, estimate: 30}
+	page, err := EstimatedCount().NewPage(context.Background(), []int{1, 2, 3}, NewPageable(1, 3), counter)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(30), page.TotalElements)
+	assert.True(page.TotalIsEstimated)
+	assert.Equal([]string{"estimatedCount"}, counter.calls)
+}
+
+func TestEstimatedCountRaisedToSeenElements(t *testing.T) {
+	counter := &fakeCounter{total: 25, estimate: 2}
+	page, err := EstimatedCount().NewPage(context.Background(), []int{1, 2, 3}, NewPageable(1, 3), counter)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(6), page.TotalElements)
+}
+
+func TestCappedCount(t *testing.T) {
+	counter := &fakeCounter{total: 25}
+	page, err := CappedCount(10).NewPage(context.Background(), []int{1, 2, 3}, NewPageable(1, 3), counter)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(10), page.TotalElements)
+	assert.True(page.TotalIsLowerBound)
+	assert.Equal([]string{"countUpTo"}, counter.calls)
+}
+
+func TestCappedCountBelowLimit(t *testing.T) {
+	counter := &fakeCounter{total: 8}
+	page, err := CappedCount(10).NewPage(context.Background(), []int{1, 2, 3}, NewPageable(1, 3), counter)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(8), page.TotalElements)
+	assert.False(page.TotalIsLowerBound)
+}
+
+func TestAsyncCountDeliversTotalLater(t *testing.T) {
+	counter := &fakeCounter{total: 25}
+	totals := make(chan int64, 1)
+	page, err := AsyncCount(func(total int64, err error) {
+		totals <- total
+	}).NewPage(context.Background(), []int{1, 2, 3}, NewPageable(1, 3), counter)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(6), page.TotalElements)
+	assert.True(page.TotalIsLowerBound)
+	assert.True(page.HasNext())
+	assert.Equal(int64(25), <-totals)
+}
+
+func TestAsyncCountWithKnownTotal(t *testing.T) {
+	var delivered int64
+	page, err := AsyncCount(func(total int64, err error) {
+		delivered = total
+	}).NewPage(context.Background(), []int{1}, NewPageable(0, 3), &fakeCounter{total: 25})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.False(page.TotalIsLowerBound)
+	assert.Equal(int64(1), delivered)
+}
+
+func TestCountStrategyFunc(t *testing.T) {
+	failure := errors.New("failure")
+	strategy := CountStrategyFunc(func(ctx context.Context, content interface{}, pageable *Pageable, counter Counter) (*Page, error) {
+		return nil, failure
+	})
+	_, err := strategy.NewPage(context.Background(), []int{}, NewPageable(0, 3), &fakeCounter{})
+
+	assert.Equal(t, failure, err)
+}
@@ -0,0 +1,70 @@
+package extsort
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+)
+
+// Encoder writes the records of a stream
+type Encoder[T any] interface {
+	Encode(record T) error
+}
+
+// Decoder reads the records of a stream, returning io.EOF after the last one
+type Decoder[T any] interface {
+	Decode() (T, error)
+}
+
+// Codec creates the encoders and decoders of the record streams spilled to the temporary files
+type Codec[T any] interface {
+	NewEncoder(w io.Writer) Encoder[T]
+	NewDecoder(r io.Reader) Decoder[T]
+}
+
+// JSONCodec creates a Codec writing the records as JSON values separated by new lines
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+// GobCodec creates a Codec writing the records with encoding/gob, which is faster and more compact than JSON for
+// records of concrete types
+func GobCodec[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return encoderFunc[T](json.NewEncoder(w).Encode)
+}
+
+func (jsonCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return decoderFunc[T](json.NewDecoder(r).Decode)
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return encoderFunc[T](gob.NewEncoder(w).Encode)
+}
+
+func (gobCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return decoderFunc[T](gob.NewDecoder(r).Decode)
+}
+
+// encoderFunc adapts the Encode method of the standard library encoders
+type encoderFunc[T any] func(value interface{}) error
+
+func (f encoderFunc[T]) Encode(record T) error {
+	return f(record)
+}
+
+// decoderFunc adapts the Decode method of the standard library decoders
+type decoderFunc[T any] func(value interface{}) error
+
+func (f decoderFunc[T]) Decode() (T, error) {
+	var record T
+	err := f(&record)
+	return record, err
+}
@@ -0,0 +1,508 @@
+// Package extsort sorts datasets larger than memory, spilling sorted runs to temporary files and merging them
+package extsort
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// ErrSorted is returned when records are added to a Sorter after its Sort method was called
+var ErrSorted = errors.New("Sorter already sorted: no more records can be added")
+
+// DefaultMemoryLimit is the default estimated size of the records buffered before spilling a run
+// DefaultFanIn is the default maximum number of runs merged at once
+// DefaultIndexInterval is the default number of records between two entries of the sorted output index
+const (
+	DefaultMemoryLimit   int64 = 64 << 20
+	DefaultFanIn         int   = 64
+	DefaultIndexInterval int   = 1024
+)
+
+// Options contains the settings of a Sorter. Zero values are replaced by the defaults.
+type Options[T any] struct {
+	// Codec writes the temporary files, JSONCodec when nil
+	Codec Codec[T]
+	// MemoryLimit is the estimated size in bytes of the records buffered before spilling a sorted run
+	MemoryLimit int64
+	// SizeOf estimates the size in bytes of a record, walking its value when nil
+	SizeOf func(record T) int64
+	// TempDir is the directory of the temporary files, os.TempDir() when empty
+	TempDir string
+	// FanIn is the maximum number of runs merged at once, merging the runs in more passes when exceeded
+	FanIn int
+	// IndexInterval is the number of records between two entries of the index used to seek the sorted output
+	IndexInterval int
+}
+
+// Sorter accumulates records, spilling sorted runs to temporary files when the memory limit is reached
+type Sorter[T any] struct {
+	compare func(a, b T) int
+	// keys resolve the sort properties of every record once, replacing compare when not nil
+	keys    *data.KeyComparator[T]
+	options Options[T]
+
+	buffer []T
+	size   int64
+	count  int64
+	runs   []string
+	sorted bool
+}
+
+// New creates a new Sorter ordering the records with the compare function
+func New[T any](compare func(a, b T) int, options Options[T]) *Sorter[T] {
+	if options.Codec == nil {
+		options.Codec = JSONCodec[T]()
+	}
+	if options.MemoryLimit <= 0 {
+		options.MemoryLimit = DefaultMemoryLimit
+	}
+	if options.SizeOf == nil {
+		options.SizeOf = func(record T) int64 {
+			return approximateSize(reflect.ValueOf(&record).Elem(), 0)
+		}
+	}
+	if options.FanIn < 2 {
+		options.FanIn = DefaultFanIn
+	}
+	if options.IndexInterval <= 0 {
+		options.IndexInterval = DefaultIndexInterval
+	}
+	return &Sorter[T]{compare: compare, options: options}
+}
+
+// NewSorted creates a new Sorter ordering the records according to the Sort, through a data.KeyComparator. The sort
+// properties of a record, including its json.RawMessage documents, are resolved once per sort and merge pass
+// instead of on every comparison.
+func NewSorted[T any](sort *data.Sort, options Options[T]) (*Sorter[T], error) {
+	keys, err := data.NewKeyComparator[T](sort, nil)
+	if err != nil {
+		return nil, err
+	}
+	sorter := New(nil, options)
+	sorter.keys = keys
+	return sorter, nil
+}
+
+// Add appends a record, spilling a sorted run when the buffered records exceed the memory limit
+func (s *Sorter[T]) Add(record T) error {
+	if s.sorted {
+		return ErrSorted
+	}
+	s.buffer = append(s.buffer, record)
+	s.size += s.options.SizeOf(record)
+	s.count++
+	if s.size >= s.options.MemoryLimit {
+		return s.spill()
+	}
+	return nil
+}
+
+// AddAll appends every record of the sequence, stopping at the first error
+func (s *Sorter[T]) AddAll(ctx context.Context, records iter.Seq[T]) error {
+	for record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.Add(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sort sorts the added records and returns the result, which must be closed to remove its temporary files. Records
+// fitting in memory are never written to disk.
+func (s *Sorter[T]) Sort(ctx context.Context) (*Result[T], error) {
+	if s.sorted {
+		return nil, ErrSorted
+	}
+	s.sorted = true
+	if len(s.runs) == 0 {
+		s.sortBuffer()
+		records := s.buffer
+		s.buffer = nil
+		return &Result[T]{records: records, count: s.count}, nil
+	}
+	if len(s.buffer) > 0 {
+		if err := s.spill(); err != nil {
+			return nil, err
+		}
+	}
+	for len(s.runs) > s.options.FanIn {
+		var merged []string
+		for start := 0; start < len(s.runs); start += s.options.FanIn {
+			group := s.runs[start:min(start+s.options.FanIn, len(s.runs))]
+			path, _, err := s.write(ctx, group, math.MaxInt)
+			if err != nil {
+				s.runs = append(s.runs, merged...)
+				return nil, err
+			}
+			merged = append(merged, path)
+		}
+		s.Close()
+		s.runs = merged
+	}
+	path, index, err := s.write(ctx, s.runs, s.options.IndexInterval)
+	if err != nil {
+		return nil, err
+	}
+	s.Close()
+	return &Result[T]{count: s.count, path: path, index: index, interval: s.options.IndexInterval, codec: s.options.Codec}, nil
+}
+
+// Close removes the temporary files of the runs not yet merged
+func (s *Sorter[T]) Close() error {
+	var first error
+	for _, run := range s.runs {
+		if err := os.Remove(run); err != nil && first == nil {
+			first = err
+		}
+	}
+	s.runs = nil
+	return first
+}
+
+// sortBuffer sorts the buffered records, keeping the order of the equal ones
+func (s *Sorter[T]) sortBuffer() {
+	if s.keys != nil {
+		s.keys.Sort(s.buffer)
+		return
+	}
+	slices.SortStableFunc(s.buffer, s.compare)
+}
+
+// spill writes the buffered records as a sorted run
+func (s *Sorter[T]) spill() error {
+	s.sortBuffer()
+	file, err := os.CreateTemp(s.options.TempDir, "extsort-run-*")
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, file.Name())
+	writer := bufio.NewWriter(file)
+	encoder := s.options.Codec.NewEncoder(writer)
+	for _, record := range s.buffer {
+		if err := encoder.Encode(record); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	clear(s.buffer)
+	s.buffer, s.size = s.buffer[:0], 0
+	return file.Close()
+}
+
+// write merges the runs into a new file, starting a new block every interval records, and returns its path with
+// the offsets of the blocks
+func (s *Sorter[T]) write(ctx context.Context, runs []string, interval int) (string, []int64, error) {
+	file, err := os.CreateTemp(s.options.TempDir, "extsort-*")
+	if err != nil {
+		return "", nil, err
+	}
+	writer := bufio.NewWriter(file)
+	encoder := &indexedEncoder[T]{codec: s.options.Codec, writer: &countingWriter{writer: writer}, interval: interval}
+	err = s.merge(ctx, runs, encoder.Encode)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", nil, err
+	}
+	return file.Name(), encoder.index, nil
+}
+
+// merge reads the sorted runs, emitting their records in order. Ties are emitted in run order, keeping the sort
+// stable.
+func (s *Sorter[T]) merge(ctx context.Context, runs []string, emit func(record T) error) error {
+	queue := &runQueue[T]{compare: s.compare, keys: s.keys}
+	for i, path := range runs {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		run := &run[T]{index: i, decoder: s.options.Codec.NewDecoder(bufio.NewReader(file)), keys: s.keys}
+		if ok, err := run.next(); err != nil {
+			return err
+		} else if ok {
+			heap.Push(queue, run)
+		}
+	}
+	for emitted := 0; queue.Len() > 0; emitted++ {
+		if emitted%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		run := queue.runs[0]
+		if err := emit(run.head); err != nil {
+			return err
+		}
+		ok, err := run.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(queue, 0)
+		} else {
+			heap.Pop(queue)
+		}
+	}
+	return nil
+}
+
+// Result is the sorted sequence of the records, accessible sequentially or by page
+type Result[T any] struct {
+	count int64
+	// records holds the sorted records when they fit in memory
+	records []T
+	// path is the file holding the sorted records, encoded in blocks starting at the index offsets
+	path     string
+	index    []int64
+	interval int
+	codec    Codec[T]
+}
+
+// Len returns the number of sorted records
+func (r *Result[T]) Len() int64 {
+	return r.count
+}
+
+// All returns an iterator over the sorted records. The iteration stops after yielding the first error.
+func (r *Result[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		err := r.read(0, r.count, func(record T) bool {
+			return yield(record, nil)
+		})
+		if err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// FetchPage returns the requested page of the sorted records, seeking the sorted output through its index. Unpaged
+// requests return every record. The Sort of the request is ignored, as the records are already sorted.
+func (r *Result[T]) FetchPage(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+	if err := pageable.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	start, end := int64(0), r.count
+	if pageable.IsPaged() {
+		start = min(pageable.Offset(), r.count)
+		end = min(start+int64(pageable.Size), r.count)
+	}
+	content := make([]T, 0, end-start)
+	err := r.read(start, end, func(record T) bool {
+		content = append(content, record)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data.NewPage(content, pageable, r.count)
+}
+
+// Close removes the temporary file of the result
+func (r *Result[T]) Close() error {
+	r.records = nil
+	if r.path == "" {
+		return nil
+	}
+	path := r.path
+	r.path = ""
+	return os.Remove(path)
+}
+
+// read emits the records from start to end, excluded, until emit returns false
+func (r *Result[T]) read(start, end int64, emit func(record T) bool) error {
+	if r.path == "" {
+		for _, record := range r.records[min(start, int64(len(r.records))):min(end, int64(len(r.records)))] {
+			if !emit(record) {
+				return nil
+			}
+		}
+		return nil
+	}
+	if start >= end {
+		return nil
+	}
+	file, err := os.Open(r.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	interval := int64(r.interval)
+	for block := start / interval; block*interval < end; block++ {
+		if _, err := file.Seek(r.index[block], io.SeekStart); err != nil {
+			return err
+		}
+		decoder := r.codec.NewDecoder(bufio.NewReader(file))
+		for position := block * interval; position < min((block+1)*interval, end); position++ {
+			record, err := decoder.Decode()
+			if err != nil {
+				return err
+			}
+			if position >= start && !emit(record) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// run is a sorted run being merged
+type run[T any] struct {
+	index   int
+	decoder Decoder[T]
+	head    T
+	keys    *data.KeyComparator[T]
+	key     data.SortKey
+}
+
+// next reads the following record of the run, returning false at its end
+func (r *run[T]) next() (bool, error) {
+	record, err := r.decoder.Decode()
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	r.head = record
+	if r.keys != nil {
+		r.key = r.keys.Key(record)
+	}
+	return true, nil
+}
+
+// runQueue is a heap of the runs ordered by their head record, then by their index
+type runQueue[T any] struct {
+	runs    []*run[T]
+	compare func(a, b T) int
+	keys    *data.KeyComparator[T]
+}
+
+func (q *runQueue[T]) Len() int {
+	return len(q.runs)
+}
+
+func (q *runQueue[T]) Less(i, j int) bool {
+	var result int
+	if q.keys != nil {
+		result = q.keys.Compare(q.runs[i].key, q.runs[j].key)
+	} else {
+		result = q.compare(q.runs[i].head, q.runs[j].head)
+	}
+	if result != 0 {
+		return result < 0
+	}
+	return q.runs[i].index < q.runs[j].index
+}
+
+func (q *runQueue[T]) Swap(i, j int) {
+	q.runs[i], q.runs[j] = q.runs[j], q.runs[i]
+}
+
+func (q *runQueue[T]) Push(x any) {
+	q.runs = append(q.runs, x.(*run[T]))
+}
+
+func (q *runQueue[T]) Pop() any {
+	last := q.runs[len(q.runs)-1]
+	q.runs = q.runs[:len(q.runs)-1]
+	return last
+}
+
+// indexedEncoder writes the records in blocks of interval records, each with its own encoder, recording the offset
+// of every block so that it can be decoded independently
+type indexedEncoder[T any] struct {
+	codec    Codec[T]
+	writer   *countingWriter
+	interval int
+	encoder  Encoder[T]
+	index    []int64
+	count    int
+}
+
+func (e *indexedEncoder[T]) Encode(record T) error {
+	if e.count%e.interval == 0 {
+		e.index = append(e.index, e.writer.count)
+		e.encoder = e.codec.NewEncoder(e.writer)
+	}
+	e.count++
+	return e.encoder.Encode(record)
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += int64(n)
+	return n, err
+}
+
+// approximateSize estimates the memory held by a value, following pointers, slices and maps up to a few levels
+func approximateSize(value reflect.Value, depth int) int64 {
+	if !value.IsValid() {
+		return 0
+	}
+	size := int64(value.Type().Size())
+	if depth > 8 {
+		return size
+	}
+	switch value.Kind() {
+	case reflect.String:
+		size += int64(value.Len())
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			size += approximateSize(value.Elem(), depth+1)
+		}
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return size + int64(value.Len())
+		}
+		for i := 0; i < value.Len(); i++ {
+			size += approximateSize(value.Index(i), depth+1)
+		}
+	case reflect.Array:
+		size = 0
+		for i := 0; i < value.Len(); i++ {
+			size += approximateSize(value.Index(i), depth+1)
+		}
+	case reflect.Map:
+		for iter := value.MapRange(); iter.Next(); {
+			size += approximateSize(iter.Key(), depth+1) + approximateSize(iter.Value(), depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			size += approximateSize(value.Field(i), depth+1) - int64(value.Field(i).Type().Size())
+		}
+	}
+	return size
+}
@@ -0,0 +1,203 @@
+package extsort
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+type record struct {
+	ID    int    `json:"id"`
+	Group string `json:"group"`
+}
+
+func records(n int) []record {
+	random := rand.New(rand.NewSource(42))
+	result := make([]record, n)
+	for i := range result {
+		result[i] = record{ID: i, Group: string(rune('a' + random.Intn(5)))}
+	}
+	random.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+	return result
+}
+
+func expected(input []record) []record {
+	sorted := slices.Clone(input)
+	slices.SortStableFunc(sorted, func(a, b record) int {
+		return cmp.Or(cmp.Compare(a.Group, b.Group), cmp.Compare(b.ID, a.ID))
+	})
+	return sorted
+}
+
+func collect(t *testing.T, result *Result[record]) []record {
+	var sorted []record
+	for item, err := range result.All() {
+		assert.Nil(t, err)
+		sorted = append(sorted, item)
+	}
+	return sorted
+}
+
+func tempFiles(t *testing.T, dir string) int {
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	return len(entries)
+}
+
+func TestSorterSpillsAndMergesRuns(t *testing.T) {
+	for name, codec := range map[string]Codec[record]{"json": JSONCodec[record](), "gob": GobCodec[record]()} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			input := records(1000)
+			sorter, err := NewSorted(data.NewSort(data.OrderBy("group", data.Asc), data.OrderBy("id", data.Desc)), Options[record]{
+				Codec:         codec,
+				MemoryLimit:   100,
+				SizeOf:        func(record) int64 { return 1 },
+				TempDir:       dir,
+				FanIn:         4,
+				IndexInterval: 7,
+			})
+			assert.Nil(t, err)
+			assert.Nil(t, sorter.AddAll(context.Background(), slices.Values(input)))
+			assert.Equal(t, 10, tempFiles(t, dir))
+
+			result, err := sorter.Sort(context.Background())
+			assert.Nil(t, err)
+			defer result.Close()
+			assert.Equal(t, int64(1000), result.Len())
+			assert.Equal(t, expected(input), collect(t, result))
+			assert.Equal(t, 1, tempFiles(t, dir))
+
+			page, err := result.FetchPage(context.Background(), data.NewPageable(3, 30))
+			assert.Nil(t, err)
+			assert.Equal(t, expected(input)[90:120], page.Content)
+			assert.Equal(t, 34, page.TotalPages)
+
+			assert.Nil(t, result.Close())
+			assert.Equal(t, 0, tempFiles(t, dir))
+		})
+	}
+}
+
+func TestSorterWithDocuments(t *testing.T) {
+	input := records(200)
+	documents := make([]json.RawMessage, len(input))
+	for i, item := range input {
+		documents[i], _ = json.Marshal(item)
+	}
+	sorter, err := NewSorted(data.NewSort(data.OrderBy("group", data.Asc), data.OrderBy("id", data.Desc)), Options[json.RawMessage]{
+		MemoryLimit: 30,
+		SizeOf:      func(json.RawMessage) int64 { return 1 },
+		TempDir:     t.TempDir(),
+		FanIn:       3,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, sorter.AddAll(context.Background(), slices.Values(documents)))
+	result, err := sorter.Sort(context.Background())
+	assert.Nil(t, err)
+	defer result.Close()
+
+	var sorted []record
+	for document, err := range result.All() {
+		assert.Nil(t, err)
+		var item record
+		assert.Nil(t, json.Unmarshal(document, &item))
+		sorted = append(sorted, item)
+	}
+	assert.Equal(t, expected(input), sorted)
+}
+
+func TestSorterInMemory(t *testing.T) {
+	dir := t.TempDir()
+	input := records(50)
+	sorter, _ := NewSorted(data.NewSort(data.OrderBy("group", data.Asc), data.OrderBy("id", data.Desc)), Options[record]{TempDir: dir})
+	for _, item := range input {
+		assert.Nil(t, sorter.Add(item))
+	}
+
+	result, err := sorter.Sort(context.Background())
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(expected(input), collect(t, result))
+	assert.Equal(0, tempFiles(t, dir))
+	page, err := result.FetchPage(context.Background(), data.NewPageable(2, 20))
+	assert.Nil(err)
+	assert.Equal(expected(input)[40:], page.Content)
+	assert.True(page.IsLast())
+	assert.Equal(ErrSorted, sorter.Add(record{}))
+	_, err = sorter.Sort(context.Background())
+	assert.Equal(ErrSorted, err)
+}
+
+func TestResultFetchPage(t *testing.T) {
+	sorter := New(func(a, b int) int { return cmp.Compare(a, b) }, Options[int]{MemoryLimit: 10, SizeOf: func(int) int64 { return 1 }, TempDir: t.TempDir(), IndexInterval: 4})
+	for i := 25; i > 0; i-- {
+		sorter.Add(i)
+	}
+	result, err := sorter.Sort(context.Background())
+	assert.Nil(t, err)
+	defer result.Close()
+
+	assert := assert.New(t)
+	page, err := result.FetchPage(context.Background(), data.NewOffsetPageable(6, 5, nil))
+	assert.Nil(err)
+	assert.Equal([]int{7, 8, 9, 10, 11}, page.Content)
+	page, err = result.FetchPage(context.Background(), data.NewPageable(10, 5))
+	assert.Nil(err)
+	assert.Equal([]int{}, page.Content)
+	assert.Equal(int64(25), page.TotalElements)
+	page, err = result.FetchPage(context.Background(), data.Unpaged())
+	assert.Nil(err)
+	assert.Equal(25, page.NumberOfElements())
+	_, err = result.FetchPage(context.Background(), data.NewPageable(-1, 5))
+	assert.Equal(data.ErrInvalidPage, err)
+
+	var items []int
+	for item := range data.Items[int](context.Background(), result, data.NewPageable(0, 10)) {
+		items = append(items, item)
+	}
+	assert.Equal(25, len(items))
+	assert.True(slices.IsSorted(items))
+}
+
+func TestSorterWithCancelledContext(t *testing.T) {
+	sorter := New(cmp.Compare[int], Options[int]{MemoryLimit: 2, SizeOf: func(int) int64 { return 1 }, TempDir: t.TempDir()})
+	for i := 0; i < 10; i++ {
+		sorter.Add(i)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := sorter.Sort(ctx)
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, sorter.Close())
+}
+
+func TestNewSortedWithUnknownProperty(t *testing.T) {
+	_, err := NewSorted[record](data.SortByProperties("missing"), Options[record]{})
+
+	assert.Equal(t, data.ErrUnknownProperty, err)
+}
+
+func TestApproximateSize(t *testing.T) {
+	type nested struct {
+		Name  string
+		Tags  []string
+		Attrs map[string]int
+	}
+	small := approximateSize(reflect.ValueOf(nested{Name: "a"}), 0)
+	large := approximateSize(reflect.ValueOf(nested{Name: "a", Tags: []string{"some long tag value"}, Attrs: map[string]int{"x": 1}}), 0)
+
+	assert.True(t, small >= int64(reflect.TypeOf(nested{}).Size()))
+	assert.True(t, large > small+19)
+}
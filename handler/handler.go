@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	data "gopkg.in/streamtune/data.v1"
+	"gopkg.in/streamtune/data.v1/parser"
+	"gopkg.in/streamtune/data.v1/render"
+)
+
+// ErrNoPage is returned when the fetch function returns neither a page nor an error
+var ErrNoPage = errors.New("No page returned by the fetch function")
+
+// FetchFunc loads the requested page, usually from a repository
+type FetchFunc func(ctx context.Context, pageable *data.Pageable) (*data.Page, error)
+
+// Policy adjusts or rejects the parsed page request before it's fetched
+type Policy func(pageable *data.Pageable) (*data.Pageable, error)
+
+// Renderer writes the fetched page into the response
+type Renderer func(w http.ResponseWriter, req *http.Request, page *data.Page) error
+
+// ErrorMapper converts an error into the status code and message of the error response
+type ErrorMapper func(err error) (int, string)
+
+// Options contains the settings of a paged handler. Zero values are replaced by the defaults.
+type Options struct {
+	// Params are the parsing parameters, whose zero fields are taken from parser.DefaultParams()
+	Params parser.Params
+	// Policy is applied to every parsed request when not nil
+	Policy Policy
+	// Renderer writes the page, render.WritePage when nil
+	Renderer Renderer
+	// ErrorMapper converts the errors, DefaultErrorMapper when nil
+	ErrorMapper ErrorMapper
+}
+
+// ErrorResponse is the JSON body of the error responses
+type ErrorResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// PagedHandler creates an http.Handler parsing the page request, applying the policy, fetching the page and
+// rendering it, with every error rendered as an ErrorResponse
+func PagedHandler(fetch FetchFunc, options Options) http.Handler {
+	options.Params = withDefaults(options.Params)
+	if options.Renderer == nil {
+		options.Renderer = render.WritePage
+	}
+	if options.ErrorMapper == nil {
+		options.ErrorMapper = DefaultErrorMapper
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		page, err := handle(fetch, options, req)
+		if err == nil {
+			err = options.Renderer(w, req, page)
+			if err == nil {
+				return
+			}
+		}
+		status, message := options.ErrorMapper(err)
+		render.JSON(w, status, ErrorResponse{status, message})
+	})
+}
+
+// withDefaults fills the zero fields of the parsing parameters with the default ones
+func withDefaults(params parser.Params) parser.Params {
+	defaults := parser.DefaultParams()
+	if params.PageParam == "" {
+		params.PageParam = defaults.PageParam
+	}
+	if params.SizeParam == "" {
+		params.SizeParam = defaults.SizeParam
+	}
+	if params.SortParam == "" {
+		params.SortParam = defaults.SortParam
+	}
+	if params.DefaultPage == 0 {
+		params.DefaultPage = defaults.DefaultPage
+	}
+	if params.DefaultSize == 0 {
+		params.DefaultSize = defaults.DefaultSize
+	}
+	return params
+}
+
+func handle(fetch FetchFunc, options Options, req *http.Request) (*data.Page, error) {
+	pageable, err := parser.ParseHTTPRequestWithParams(req, options.Params)
+	if err != nil {
+		return nil, err
+	}
+	if options.Policy != nil {
+		if pageable, err = options.Policy(pageable); err != nil {
+			return nil, err
+		}
+	}
+	page, err := fetch(req.Context(), pageable)
+	if err == nil && page == nil {
+		return nil, ErrNoPage
+	}
+	return page, err
+}
+
+// MaxSize returns the policy capping the requested page size to the provided value
+func MaxSize(size int) Policy {
+	return func(pageable *data.Pageable) (*data.Pageable, error) {
+		if pageable.IsPaged() && pageable.Size > size {
+			return pageable.WithSize(size), nil
+		}
+		return pageable, nil
+	}
+}
+
+// DefaultErrorMapper maps the parsing and validation errors to 400 Bad Request, and any other error to 500
+// Internal Server Error without disclosing it
+func DefaultErrorMapper(err error) (int, string) {
+	for _, badRequest := range []error{
+		parser.ErrWrongPageValues, parser.ErrInvalidPageValue, parser.ErrWrongSizeValues, parser.ErrInvalidSizeValue,
+		parser.ErrWrongSortValue, data.ErrInvalidDirection, data.ErrInvalidPage, data.ErrInvalidSize,
+		data.ErrOffsetOverflow,
+	} {
+		if errors.Is(err, badRequest) {
+			return http.StatusBadRequest, err.Error()
+		}
+	}
+	return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+	"gopkg.in/streamtune/data.v1/parser"
+)
+
+var items = []string{"a", "b", "c", "d", "e"}
+
+func fetchItems(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+	start := int(pageable.Offset())
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + pageable.Size
+	if end > len(items) {
+		end = len(items)
+	}
+	return data.NewPage(items[start:end], pageable, int64(len(items)))
+}
+
+func serve(handler http.Handler, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", target, nil))
+	return recorder
+}
+
+func TestPagedHandlerRendersPage(t *testing.T) {
+	recorder := serve(PagedHandler(fetchItems, Options{}), "/items?page=1&size=2")
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.NotEmpty(recorder.Header().Get("ETag"))
+	assert.JSONEq(`{"content":["c","d"],"number":1,"size":2,"totalPages":3,"totalElements":5,"sort":{"orders":[]}}`, recorder.Body.String())
+}
+
+func TestPagedHandlerWithInvalidRequest(t *testing.T) {
+	recorder := serve(PagedHandler(fetchItems, Options{}), "/items?size=0")
+
+	var response ErrorResponse
+	assert := assert.New(t)
+	assert.Equal(http.StatusBadRequest, recorder.Code)
+	assert.Nil(json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(ErrorResponse{http.StatusBadRequest, parser.ErrInvalidSizeValue.Error()}, response)
+}
+
+func TestPagedHandlerWithFetchError(t *testing.T) {
+	handler := PagedHandler(func(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+		return nil, errors.New("connection refused")
+	}, Options{})
+	recorder := serve(handler, "/items")
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusInternalServerError, recorder.Code)
+	assert.NotContains(recorder.Body.String(), "connection refused")
+}
+
+func TestPagedHandlerWithOptions(t *testing.T) {
+	params := parser.DefaultParams()
+	params.SizeParam = "limit"
+	var fetched *data.Pageable
+	handler := PagedHandler(func(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+		fetched = pageable
+		return fetchItems(ctx, pageable)
+	}, Options{
+		Params: params,
+		Policy: MaxSize(3),
+		Renderer: func(w http.ResponseWriter, req *http.Request, page *data.Page) error {
+			w.WriteHeader(http.StatusTeapot)
+			return nil
+		},
+		ErrorMapper: func(err error) (int, string) {
+			return http.StatusConflict, "mapped"
+		},
+	})
+	recorder := serve(handler, "/items?limit=50")
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusTeapot, recorder.Code)
+	assert.Equal(3, fetched.Size)
+	assert.Equal(http.StatusConflict, serve(handler, "/items?limit=x").Code)
+}
+
+func TestPagedHandlerWithPartialParams(t *testing.T) {
+	var requested *data.Pageable
+	fetch := func(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+		requested = pageable
+		return fetchItems(ctx, pageable)
+	}
+	recorder := serve(PagedHandler(fetch, Options{Params: parser.Params{TieBreaker: "id"}}), "/items?page=0")
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.Equal(parser.DefaultParams().DefaultSize, requested.Size)
+	assert.Equal(data.SortBy(data.Asc, "id"), requested.Sort)
+}
+
+func TestPagedHandlerWithNilPage(t *testing.T) {
+	handler := PagedHandler(func(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+		return nil, nil
+	}, Options{})
+
+	assert.Equal(t, http.StatusInternalServerError, serve(handler, "/items").Code)
+}
@@ -0,0 +1,68 @@
+package data
+
+import (
+	"context"
+	"iter"
+)
+
+// PageFetcher loads the pages of a data source
+type PageFetcher interface {
+	FetchPage(ctx context.Context, pageable *Pageable) (*Page, error)
+}
+
+// PageFetcherFunc is an adapter to allow the use of ordinary functions as page fetchers
+type PageFetcherFunc func(ctx context.Context, pageable *Pageable) (*Page, error)
+
+// FetchPage calls f(ctx, pageable)
+func (f PageFetcherFunc) FetchPage(ctx context.Context, pageable *Pageable) (*Page, error) {
+	return f(ctx, pageable)
+}
+
+// Pages returns an iterator over the pages of the fetcher, from the provided request up to the last page or the
+// first empty one. An unpaged request yields a single page. The iteration stops after yielding the first error,
+// including the cancellation of the context.
+func Pages(ctx context.Context, fetcher PageFetcher, start *Pageable) iter.Seq2[*Page, error] {
+	return func(yield func(*Page, error) bool) {
+		for pageable := start; ; pageable = pageable.Next() {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			page, err := fetcher.FetchPage(ctx, pageable)
+			if err == nil && page == nil {
+				err = ErrInvalidContent
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) || !page.HasContent() || !page.HasNext() || pageable.IsUnpaged() {
+				return
+			}
+		}
+	}
+}
+
+// Items returns an iterator over the elements of the fetcher pages, whose content must be a []T, from the provided
+// request up to the last page. The iteration stops after yielding the first error, with the zero value of T.
+func Items[T any](ctx context.Context, fetcher PageFetcher, start *Pageable) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for page, err := range Pages(ctx, fetcher, start) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			content, ok := page.Content.([]T)
+			if !ok {
+				yield(zero, ErrInvalidContent)
+				return
+			}
+			for _, item := range content {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceFetcher serves the pages of a slice, counting the fetches
+type sliceFetcher struct {
+	items   []int
+	fetches int
+}
+
+func (f *sliceFetcher) FetchPage(ctx context.Context, pageable *Pageable) (*Page, error) {
+	f.fetches++
+	start := int(pageable.Offset())
+	if start > len(f.items) {
+		start = len(f.items)
+	}
+	end := start + pageable.Size
+	if end > len(f.items) || pageable.IsUnpaged() {
+		end = len(f.items)
+	}
+	return NewPage(f.items[start:end], pageable, int64(len(f.items)))
+}
+
+func TestPagesWalksEveryPage(t *testing.T) {
+	fetcher := &sliceFetcher{items: []int{1, 2, 3, 4, 5}}
+	var numbers []int
+	for page, err := range Pages(context.Background(), fetcher, NewPageable(0, 2)) {
+		assert.Nil(t, err)
+		numbers = append(numbers, page.Number)
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, numbers)
+	assert.Equal(t, 3, fetcher.fetches)
+}
+
+func TestPagesOnEmptyResult(t *testing.T) {
+	fetcher := &sliceFetcher{}
+	count := 0
+	for range Pages(context.Background(), fetcher, NewPageable(0, 2)) {
+		count++
+	}
+
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 1, fetcher.fetches)
+}
+
+func TestPagesOnUnpaged(t *testing.T) {
+	fetcher := &sliceFetcher{items: []int{1, 2, 3}}
+	for range Pages(context.Background(), fetcher, Unpaged()) {
+	}
+
+	assert.Equal(t, 1, fetcher.fetches)
+}
+
+func TestPagesOnUnpagedWithPagedResult(t *testing.T) {
+	fetches := 0
+	fetcher := PageFetcherFunc(func(ctx context.Context, pageable *Pageable) (*Page, error) {
+		fetches++
+		return NewPage([]int{1, 2}, NewPageable(0, 2), 10)
+	})
+	var items []int
+	for item, err := range Items[int](context.Background(), fetcher, Unpaged()) {
+		assert.Nil(t, err)
+		items = append(items, item)
+	}
+
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, 1, fetches)
+}
+
+func TestPagesStopsOnError(t *testing.T) {
+	failure := errors.New("failure")
+	fetcher := PageFetcherFunc(func(ctx context.Context, pageable *Pageable) (*Page, error) {
+		if pageable.Page == 1 {
+			return nil, failure
+		}
+		return NewPage([]int{1, 2}, pageable, 10)
+	})
+	var errs []error
+	for _, err := range Pages(context.Background(), fetcher, NewPageable(0, 2)) {
+		errs = append(errs, err)
+	}
+
+	assert.Equal(t, []error{nil, failure}, errs)
+}
+
+func TestPagesStopsOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := &sliceFetcher{items: []int{1, 2, 3, 4, 5}}
+	var last error
+	for _, err := range Pages(ctx, fetcher, NewPageable(0, 2)) {
+		cancel()
+		last = err
+	}
+
+	assert.Equal(t, context.Canceled, last)
+	assert.Equal(t, 1, fetcher.fetches)
+}
+
+func TestPagesStopsWhenConsumerBreaks(t *testing.T) {
+	fetcher := &sliceFetcher{items: []int{1, 2, 3, 4, 5}}
+	for range Pages(context.Background(), fetcher, NewPageable(0, 2)) {
+		break
+	}
+
+	assert.Equal(t, 1, fetcher.fetches)
+}
+
+func TestItemsWalksEveryElement(t *testing.T) {
+	fetcher := &sliceFetcher{items: []int{1, 2, 3, 4, 5}}
+	var items []int
+	for item, err := range Items[int](context.Background(), fetcher, NewPageable(0, 2)) {
+		assert.Nil(t, err)
+		items = append(items, item)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+}
+
+func TestItemsWithWrongContentType(t *testing.T) {
+	fetcher := &sliceFetcher{items: []int{1, 2, 3}}
+	var last error
+	for _, err := range Items[string](context.Background(), fetcher, NewPageable(0, 2)) {
+		last = err
+	}
+
+	assert.Equal(t, ErrInvalidContent, last)
+}
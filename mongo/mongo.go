@@ -1,13 +1,41 @@
 package mongo
 
 import (
-	data "gopkg.in/streamtune/data.v1"
+	"context"
+	"math"
+	"reflect"
+
 	"gopkg.in/mgo.v2"
+	data "gopkg.in/streamtune/data.v1"
 )
 
-// ApplyPageable will apply the given pageable object to provided query parameters, returning the updated query
+// ApplyPageable will apply the given pageable object to provided query parameters, returning the updated query.
+// Unpaged requests do not skip or limit the results and unsorted ones leave the query order unchanged.
+// Offsets overflowing an int are saturated: FindPage and FindSlice return data.ErrOffsetOverflow instead.
 func ApplyPageable(pageable *data.Pageable, query *mgo.Query) *mgo.Query {
-	return applySort(pageable.Sort.Orders, query.Skip(pageable.Offset()).Limit(pageable.Size))
+	if pageable.IsPaged() {
+		query = query.Skip(int(min(pageable.Offset(), math.MaxInt))).Limit(pageable.Size)
+	}
+	sort := pageable.SortOrUnsorted()
+	if sort.IsUnsorted() {
+		return query
+	}
+	return applySort(sort.Orders, query)
+}
+
+// ApplyPageableWithTieBreaker will apply the given pageable object to provided query parameters, ending the sort
+// with the provided unique property to get a total ordering
+func ApplyPageableWithTieBreaker(pageable *data.Pageable, query *mgo.Query, property string) *mgo.Query {
+	return ApplyPageable(pageable.WithTieBreaker(property), query)
+}
+
+// checkOffset returns data.ErrOffsetOverflow when the documents skipped by the pageable cannot be counted by an int
+func checkOffset(pageable *data.Pageable) error {
+	offset, err := pageable.CheckedOffset()
+	if err == nil && offset > math.MaxInt {
+		err = data.ErrOffsetOverflow
+	}
+	return err
 }
 
 func applySort(ordering []data.Order, query *mgo.Query) *mgo.Query {
@@ -20,3 +48,65 @@ func applySort(ordering []data.Order, query *mgo.Query) *mgo.Query {
 	}
 	return query.Sort(fields...)
 }
+
+// FindPage will find the documents matching the selector in the provided collection for the requested page,
+// storing them in the result, which must be a pointer to a slice. The total number of documents is computed
+// with the given count strategy, or data.ExactCount when nil. Pages whose offset overflows return
+// data.ErrOffsetOverflow.
+func FindPage(ctx context.Context, collection *mgo.Collection, selector interface{}, pageable *data.Pageable, result interface{}, strategy data.CountStrategy) (*data.Page, error) {
+	if err := checkOffset(pageable); err != nil {
+		return nil, err
+	}
+	if err := ApplyPageable(pageable, collection.Find(selector)).All(result); err != nil {
+		return nil, err
+	}
+	if strategy == nil {
+		strategy = data.ExactCount()
+	}
+	content := reflect.ValueOf(result).Elem().Interface()
+	return strategy.NewPage(ctx, content, pageable, NewCounter(collection, selector))
+}
+
+// FindSlice will find the documents matching the selector in the provided collection for the requested page
+// without counting them: one more document is fetched to find out if a next slice exists
+func FindSlice(collection *mgo.Collection, selector interface{}, pageable *data.Pageable, result interface{}) (*data.Slice, error) {
+	if err := checkOffset(pageable); err != nil {
+		return nil, err
+	}
+	query := ApplyPageable(pageable, collection.Find(selector))
+	if pageable.IsPaged() {
+		query = query.Limit(pageable.Size + 1)
+	}
+	if err := query.All(result); err != nil {
+		return nil, err
+	}
+	return data.NewSliceFromLookahead(reflect.ValueOf(result).Elem().Interface(), pageable)
+}
+
+// counter counts the documents matching a selector
+type counter struct {
+	collection *mgo.Collection
+	selector   interface{}
+}
+
+// NewCounter creates the data.Counter for the documents matching the selector in the provided collection.
+// Like estimatedDocumentCount, the estimated count reads the number of documents of the whole collection from
+// its metadata, ignoring the selector.
+func NewCounter(collection *mgo.Collection, selector interface{}) data.Counter {
+	return &counter{collection, selector}
+}
+
+func (c *counter) Count(ctx context.Context) (int64, error) {
+	total, err := c.collection.Find(c.selector).Count()
+	return int64(total), err
+}
+
+func (c *counter) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	total, err := c.collection.Find(c.selector).Limit(int(limit)).Count()
+	return int64(total), err
+}
+
+func (c *counter) EstimatedCount(ctx context.Context) (int64, error) {
+	total, err := c.collection.Count()
+	return int64(total), err
+}
@@ -0,0 +1,163 @@
+package odata
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// TopParam is the OData maximum number of elements query option
+// SkipParam is the OData number of skipped elements query option
+// OrderByParam is the OData sorting query option
+// CountParam is the OData total count query option
+const (
+	TopParam     string = "$top"
+	SkipParam    string = "$skip"
+	OrderByParam string = "$orderby"
+	CountParam   string = "$count"
+	DefaultTop   int    = 10
+)
+
+// ErrWrongValues is returned when a query option is provided more than once
+// ErrInvalidTop is returned when an invalid $top value is parsed
+// ErrInvalidSkip is returned when an invalid $skip value is parsed
+// ErrInvalidOrderBy is returned when an invalid $orderby value is parsed
+// ErrInvalidCount is returned when an invalid $count value is parsed
+var (
+	ErrWrongValues    = errors.New("Wrong number of query option values, expected 1")
+	ErrInvalidTop     = errors.New("$top value must be numeric greater or equal than 1")
+	ErrInvalidSkip    = errors.New("$skip value must be numeric greater or equal than 0")
+	ErrInvalidOrderBy = errors.New("Wrong $orderby value provided: expected <p1> [asc|desc],...,<pN> [asc|desc]")
+	ErrInvalidCount   = errors.New("$count value must be either 'true' or 'false'")
+)
+
+// Params contains the default parsing parameters
+type Params struct {
+	// DefaultTop is the number of elements requested when $top is missing
+	DefaultTop int
+	// MaxTop caps the requested number of elements when greater than 0
+	MaxTop int
+}
+
+var defaultParams = Params{DefaultTop: DefaultTop}
+
+// Query holds the parsed query options
+type Query struct {
+	Pageable *data.Pageable
+	// Count reports if the total number of elements was requested
+	Count bool
+}
+
+// ParseHTTPRequest will parse the provided HTTP request query options with default parameters
+func ParseHTTPRequest(req *http.Request) (*Query, error) {
+	return ParseValuesWithParams(req.URL.Query(), defaultParams)
+}
+
+// ParseHTTPRequestWithParams will parse the provided HTTP request query options given the provided parameters
+func ParseHTTPRequestWithParams(req *http.Request, params Params) (*Query, error) {
+	return ParseValuesWithParams(req.URL.Query(), params)
+}
+
+// ParseURL will parse the provided URL query options with default parameters
+func ParseURL(url *url.URL) (*Query, error) {
+	return ParseValuesWithParams(url.Query(), defaultParams)
+}
+
+// ParseURLWithParams will parse the provided URL query options given the provided parameters
+func ParseURLWithParams(url *url.URL, params Params) (*Query, error) {
+	return ParseValuesWithParams(url.Query(), params)
+}
+
+// ParseValues will parse the provided query options with default parameters
+func ParseValues(values map[string][]string) (*Query, error) {
+	return ParseValuesWithParams(values, defaultParams)
+}
+
+// ParseValuesWithParams will parse the provided query options with given parameters into an offset based Pageable
+func ParseValuesWithParams(values map[string][]string, params Params) (*Query, error) {
+	top, err := parseInt(values, TopParam, int64(params.DefaultTop), 1, 32, ErrInvalidTop)
+	if err != nil {
+		return nil, err
+	}
+	if params.MaxTop > 0 && top > int64(params.MaxTop) {
+		top = int64(params.MaxTop)
+	}
+	skip, err := parseInt(values, SkipParam, 0, 0, 64, ErrInvalidSkip)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := parseOrderBy(values)
+	if err != nil {
+		return nil, err
+	}
+	count, err := parseCount(values)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{Pageable: data.NewOffsetPageable(skip, int(top), sort), Count: count}, nil
+}
+
+func single(values map[string][]string, param string) (string, bool, error) {
+	value, ok := values[param]
+	if !ok {
+		return "", false, nil
+	}
+	if len(value) != 1 {
+		return "", true, ErrWrongValues
+	}
+	return value[0], true, nil
+}
+
+func parseInt(values map[string][]string, param string, defaultValue, min int64, bitSize int, invalid error) (int64, error) {
+	value, ok, err := single(values, param)
+	if err != nil || !ok {
+		return defaultValue, err
+	}
+	parsed, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil || parsed < min {
+		return defaultValue, invalid
+	}
+	return parsed, nil
+}
+
+func parseOrderBy(values map[string][]string) (*data.Sort, error) {
+	value, ok, err := single(values, OrderByParam)
+	if err != nil || !ok {
+		return data.Unsorted(), err
+	}
+	sort := data.Unsorted()
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, ErrInvalidOrderBy
+		}
+		direction := data.Asc
+		if len(parts) == 2 {
+			if direction, err = data.ParseDirection(parts[1]); err != nil {
+				return nil, ErrInvalidOrderBy
+			}
+		}
+		// OData navigates properties with slashes, while data uses dotted paths
+		sort = sort.And(data.SortBy(direction, strings.ReplaceAll(parts[0], "/", ".")))
+	}
+	return sort, nil
+}
+
+func parseCount(values map[string][]string) (bool, error) {
+	value, ok, err := single(values, CountParam)
+	if err != nil || !ok {
+		return false, err
+	}
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, ErrInvalidCount
+	}
+}
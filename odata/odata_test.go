@@ -0,0 +1,68 @@
+package odata
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestParseHTTPRequest(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/odata/Products?$top=5&$skip=12&$orderby=Name%20desc,Price,Supplier/Name%20asc&$count=true", nil)
+	query, err := ParseHTTPRequest(req)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.True(query.Count)
+	assert.Equal(int64(12), query.Pageable.Offset())
+	assert.Equal(5, query.Pageable.Size)
+	assert.Equal(data.NewSort(data.OrderBy("Name", data.Desc), data.OrderByProperty("Price"), data.OrderByProperty("Supplier.Name")), query.Pageable.Sort)
+}
+
+func TestParseValuesWithDefaults(t *testing.T) {
+	query, err := ParseValues(map[string][]string{})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.False(query.Count)
+	assert.Equal(int64(0), query.Pageable.Offset())
+	assert.Equal(DefaultTop, query.Pageable.Size)
+	assert.True(query.Pageable.Sort.IsUnsorted())
+}
+
+func TestParseValuesWithMaxTop(t *testing.T) {
+	query, err := ParseValuesWithParams(map[string][]string{"$top": {"500"}}, Params{DefaultTop: 20, MaxTop: 100})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(100, query.Pageable.Size)
+}
+
+func TestParseValuesWithLargeSkip(t *testing.T) {
+	query, err := ParseValues(map[string][]string{SkipParam: {"5000000000"}, TopParam: {"5"}})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(5000000000), query.Pageable.Offset())
+
+	_, err = ParseValues(map[string][]string{TopParam: {"5000000000"}})
+	assert.Equal(ErrInvalidTop, err)
+}
+
+func TestParseValuesWithInvalidValues(t *testing.T) {
+	_, top := ParseValues(map[string][]string{"$top": {"0"}})
+	_, skip := ParseValues(map[string][]string{"$skip": {"-1"}})
+	_, orderBy := ParseValues(map[string][]string{"$orderby": {"Name sideways"}})
+	_, emptyOrderBy := ParseValues(map[string][]string{"$orderby": {"Name,"}})
+	_, count := ParseValues(map[string][]string{"$count": {"yes"}})
+	_, wrong := ParseValues(map[string][]string{"$top": {"1", "2"}})
+
+	assert := assert.New(t)
+	assert.Equal(ErrInvalidTop, top)
+	assert.Equal(ErrInvalidSkip, skip)
+	assert.Equal(ErrInvalidOrderBy, orderBy)
+	assert.Equal(ErrInvalidOrderBy, emptyOrderBy)
+	assert.Equal(ErrInvalidCount, count)
+	assert.Equal(ErrWrongValues, wrong)
+}
@@ -0,0 +1,48 @@
+package odata
+
+import (
+	"net/url"
+	"strconv"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// Response is the OData JSON representation of a page
+type Response struct {
+	Count    *int64      `json:"@odata.count,omitempty"`
+	NextLink string      `json:"@odata.nextLink,omitempty"`
+	Value    interface{} `json:"value"`
+}
+
+// NewResponse creates the OData response for the provided page, which was requested by the given URL. The total
+// number of elements is included when count is set. The next link is included when a next page exists and the
+// server returned less elements than requested, as for a capped $top or a missing one, carrying the remaining $top.
+func NewResponse(page *data.Page, requestURL *url.URL, count bool) *Response {
+	response := &Response{Value: page.Content}
+	if count {
+		total := page.TotalElements
+		response.Count = &total
+	}
+	if page.HasNext() {
+		response.NextLink = NextLink(requestURL, page)
+	}
+	return response
+}
+
+// NextLink returns the provided request URL with the $skip and $top query options of the elements following the
+// page, or an empty string when the page holds every element requested by $top. Requests without $top keep getting
+// the server page size.
+func NextLink(requestURL *url.URL, page *data.Page) string {
+	link := *requestURL
+	query := link.Query()
+	if top, err := strconv.ParseInt(query.Get(TopParam), 10, 64); err == nil {
+		remaining := top - int64(page.NumberOfElements())
+		if remaining <= 0 {
+			return ""
+		}
+		query.Set(TopParam, strconv.FormatInt(remaining, 10))
+	}
+	query.Set(SkipParam, strconv.FormatInt(page.NextPageable().Offset(), 10))
+	link.RawQuery = query.Encode()
+	return link.String()
+}
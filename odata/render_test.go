@@ -0,0 +1,50 @@
+package odata
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestNewResponseWithCountAndNextLink(t *testing.T) {
+	requestURL, _ := url.Parse("http://localhost/odata/Products?$skip=3&$count=true")
+	page, _ := data.NewPage([]string{"a", "b"}, data.NewOffsetPageable(3, 2, nil), 10)
+	encoded, err := json.Marshal(NewResponse(page, requestURL, true))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.JSONEq(`{"@odata.count":10,"@odata.nextLink":"http://localhost/odata/Products?%24count=true&%24skip=5","value":["a","b"]}`, string(encoded))
+}
+
+func TestNewResponseWithCappedTop(t *testing.T) {
+	requestURL, _ := url.Parse("http://localhost/odata/Products?$top=5&$skip=3")
+	page, _ := data.NewPage([]string{"a", "b"}, data.NewOffsetPageable(3, 2, nil), 10)
+	encoded, err := json.Marshal(NewResponse(page, requestURL, false))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.JSONEq(`{"@odata.nextLink":"http://localhost/odata/Products?%24skip=5&%24top=3","value":["a","b"]}`, string(encoded))
+}
+
+func TestNewResponseWithSatisfiedTop(t *testing.T) {
+	requestURL, _ := url.Parse("http://localhost/odata/Products?$top=2&$skip=3")
+	page, _ := data.NewPage([]string{"a", "b"}, data.NewOffsetPageable(3, 2, nil), 10)
+	encoded, err := json.Marshal(NewResponse(page, requestURL, false))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.JSONEq(`{"value":["a","b"]}`, string(encoded))
+}
+
+func TestNewResponseOnLastPage(t *testing.T) {
+	requestURL, _ := url.Parse("http://localhost/odata/Products?$skip=8")
+	page, _ := data.NewPage([]string{"a", "b"}, data.NewOffsetPageable(8, 10, nil), 10)
+	encoded, err := json.Marshal(NewResponse(page, requestURL, false))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.JSONEq(`{"value":["a","b"]}`, string(encoded))
+}
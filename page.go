@@ -2,12 +2,20 @@ package data
 
 import (
 	"errors"
-	"math"
 	"reflect"
+	"strconv"
 )
 
 // ErrInvalidContent is returned by NewPage when an invalid content is provided
-var ErrInvalidContent = errors.New("Invalid content provided: expected Array or Slice")
+// ErrInvalidTotal is returned by NewPage when a negative total number of elements is provided
+// ErrContentTooLarge is returned by NewPage when the content holds more elements than the page size
+// ErrInvalidTotalPages is returned by Page.Validate when the total pages do not match the total elements
+var (
+	ErrInvalidContent    = errors.New("Invalid content provided: expected Array or Slice")
+	ErrInvalidTotal      = errors.New("Invalid total number of elements provided: expected a value greater or equal than 0")
+	ErrContentTooLarge   = errors.New("Invalid content provided: it holds more elements than the page size")
+	ErrInvalidTotalPages = errors.New("Invalid total pages: they do not match the total number of elements and the page size")
+)
 
 // Page is the struct used tho hold a single page of data
 type Page struct {
@@ -15,46 +23,209 @@ type Page struct {
 	Number        int         `json:"number"`
 	Size          int         `json:"size"`
 	TotalPages    int         `json:"totalPages"`
-	TotalElements int         `json:"totalElements"`
+	TotalElements int64       `json:"totalElements"`
+	// TotalIsLowerBound reports that counting stopped early and there may be more than TotalElements elements
+	TotalIsLowerBound bool `json:"totalIsLowerBound,omitempty"`
+	// TotalIsEstimated reports that TotalElements is an approximation of the actual number of elements
+	TotalIsEstimated bool  `json:"totalIsEstimated,omitempty"`
+	Sort             *Sort `json:"sort"`
+	// Pageable is the request originating the page
+	Pageable *Pageable `json:"-"`
 }
 
-// NewPage create a new Page object with provided content, pagination object and total number of elements
-func NewPage(content interface{}, pageable *Pageable, totalElements int) (*Page, error) {
-	contentType := reflect.TypeOf(content)
-	kind := contentType.Kind()
-	if kind != reflect.Array && kind != reflect.Slice {
-		return nil, ErrInvalidContent
+// NewPage create a new Page object with provided content, pagination object and total number of elements.
+// A nil or unpaged pagination object produces a single page holding the whole content.
+// An empty result has no pages, and its first page is both the first and the last one.
+func NewPage(content interface{}, pageable *Pageable, totalElements int64) (*Page, error) {
+	if err := validateContent(content); err != nil {
+		return nil, err
+	}
+	if err := pageable.Validate(); err != nil {
+		return nil, err
+	}
+	if totalElements < 0 {
+		return nil, ErrInvalidTotal
+	}
+	if pageable.IsUnpaged() {
+		// the single page holds every element
+		size := reflect.ValueOf(content).Len()
+		return &Page{
+			Content:       content,
+			Number:        0,
+			Size:          size,
+			TotalPages:    totalPages(totalElements, size),
+			TotalElements: totalElements,
+			Sort:          pageable.SortOrUnsorted(),
+			Pageable:      pageable.orUnpaged(),
+		}, nil
+	}
+	if reflect.ValueOf(content).Len() > pageable.Size {
+		return nil, ErrContentTooLarge
 	}
 	return &Page{
 		Content:       content,
 		Number:        pageable.Page,
 		Size:          pageable.Size,
-		TotalPages:    int(math.Ceil(float64(totalElements) / float64(pageable.Size))),
+		TotalPages:    totalPages(totalElements, pageable.Size),
 		TotalElements: totalElements,
+		Sort:          pageable.SortOrUnsorted(),
+		Pageable:      pageable,
 	}, nil
 }
 
+// NewCappedPage create a new Page object with provided content, pagination object and a total number of elements
+// counted up to the provided limit. When the limit is reached the total is flagged as a lower bound.
+func NewCappedPage(content interface{}, pageable *Pageable, totalElements, limit int64) (*Page, error) {
+	if totalElements < limit {
+		return NewPage(content, pageable, totalElements)
+	}
+	return NewLowerBoundPage(content, pageable, limit)
+}
+
+// NewLowerBoundPage create a new Page object whose total number of elements is a lower bound of the actual one
+func NewLowerBoundPage(content interface{}, pageable *Pageable, totalElements int64) (*Page, error) {
+	page, err := NewPage(content, pageable, totalElements)
+	if err != nil {
+		return nil, err
+	}
+	page.TotalIsLowerBound = true
+	return page, nil
+}
+
+// Validate check if the page values are consistent, as they may have been decoded from an untrusted source
+func (page *Page) Validate() error {
+	if err := validateContent(page.Content); err != nil {
+		return err
+	}
+	switch {
+	case page.Number < 0:
+		return ErrInvalidPage
+	case page.Size < 0:
+		return ErrInvalidSize
+	case page.TotalElements < 0:
+		return ErrInvalidTotal
+	case reflect.ValueOf(page.Content).Len() > page.Size:
+		return ErrContentTooLarge
+	case page.TotalPages != totalPages(page.TotalElements, page.Size):
+		return ErrInvalidTotalPages
+	}
+	return nil
+}
+
+func validateContent(content interface{}) error {
+	if content == nil {
+		return ErrInvalidContent
+	}
+	kind := reflect.TypeOf(content).Kind()
+	if kind != reflect.Array && kind != reflect.Slice {
+		return ErrInvalidContent
+	}
+	return nil
+}
+
+// totalPages returns the number of pages of provided size needed to hold the total elements
+func totalPages(totalElements int64, size int) int {
+	if totalElements <= 0 {
+		return 0
+	}
+	if size <= 0 {
+		return 1
+	}
+	return toInt((totalElements-1)/int64(size) + 1)
+}
+
 // HasPrevious check if the page has a page before this one
 func (page *Page) HasPrevious() bool {
+	if page.Pageable.IsOffsetBased() {
+		return page.Pageable.HasPrevious()
+	}
 	return page.Number > 0
 }
 
-// HasNext check if the page as a page over this one
+// HasNext check if the page as a page over this one. When the total is a lower bound a full page at or past the
+// counted ones may have a next page too.
 func (page *Page) HasNext() bool {
+	if page.Pageable.IsOffsetBased() {
+		end := page.Pageable.Offset() + int64(page.NumberOfElements())
+		if page.TotalIsLowerBound && end >= page.TotalElements {
+			return page.NumberOfElements() == page.Size
+		}
+		return end < page.TotalElements
+	}
+	if page.TotalIsLowerBound && page.Number >= (page.TotalPages-1) {
+		return page.Size > 0 && page.NumberOfElements() == page.Size
+	}
 	return page.Number < (page.TotalPages - 1)
 }
 
 // IsFirst check if the page is the first one
 func (page *Page) IsFirst() bool {
+	if page.Pageable.IsOffsetBased() {
+		return !page.HasPrevious()
+	}
 	return page.Number == 0
 }
 
-// IsLast check if the page si the last one
+// IsLast check if the page si the last one. The first page of an empty result is the last one too.
 func (page *Page) IsLast() bool {
-	return (page.Number + 1) == page.TotalPages
+	if page.Pageable.IsOffsetBased() {
+		return !page.HasNext()
+	}
+	if page.TotalIsLowerBound {
+		return page.Number >= (page.TotalPages-1) && !page.HasNext()
+	}
+	return (page.Number+1) == page.TotalPages || (page.TotalPages == 0 && page.Number == 0)
 }
 
 // HasContent check if the page has content
 func (page *Page) HasContent() bool {
-	return reflect.ValueOf(page.Content).Len() > 0
+	return page.NumberOfElements() > 0
+}
+
+// NumberOfElements returns the number of elements held by the page
+func (page *Page) NumberOfElements() int {
+	return reflect.ValueOf(page.Content).Len()
+}
+
+// NextPageable returns the request for the next page, or nil if the page is the last one
+func (page *Page) NextPageable() *Pageable {
+	if !page.HasNext() {
+		return nil
+	}
+	return page.request().Next()
+}
+
+// PreviousPageable returns the request for the previous page, or nil if the page is the first one
+func (page *Page) PreviousPageable() *Pageable {
+	if !page.HasPrevious() {
+		return nil
+	}
+	return page.request().Previous()
+}
+
+// LastPageable returns the request for the last page
+func (page *Page) LastPageable() *Pageable {
+	request := page.request()
+	if request.IsUnpaged() {
+		return request.First()
+	}
+	return request.Last(page.TotalElements)
+}
+
+// request returns the request originating the page, or the one matching its number, size and sort when the page
+// was decoded or built without it
+func (page *Page) request() *Pageable {
+	if page.Pageable != nil {
+		return page.Pageable
+	}
+	return NewSortedPageable(page.Number, page.Size, page.Sort)
+}
+
+// FormatTotal returns the total number of elements as text, followed by a plus sign when it's a lower bound
+func (page *Page) FormatTotal() string {
+	total := strconv.FormatInt(page.TotalElements, 10)
+	if page.TotalIsLowerBound {
+		return total + "+"
+	}
+	return total
 }
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,7 +17,7 @@ func TestNewPageWithArray(t *testing.T) {
 	assert.Equal(content, page.Content)
 	assert.Equal(1, page.Number)
 	assert.Equal(3, page.Size)
-	assert.Equal(10, page.TotalElements)
+	assert.Equal(int64(10), page.TotalElements)
 	assert.Equal(4, page.TotalPages)
 }
 
@@ -30,7 +31,7 @@ func TestNewPageWithSlice(t *testing.T) {
 	assert.Equal(content, page.Content)
 	assert.Equal(1, page.Number)
 	assert.Equal(3, page.Size)
-	assert.Equal(10, page.TotalElements)
+	assert.Equal(int64(10), page.TotalElements)
 	assert.Equal(4, page.TotalPages)
 }
 
@@ -126,3 +127,217 @@ func TestHasContentWithSlice(t *testing.T) {
 
 	assert.True(t, page.HasContent())
 }
+
+func TestNewPageWithUnpagedHoldsWholeContent(t *testing.T) {
+	page, err := NewPage([]int{1, 2, 3}, Unpaged(), 3)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(0, page.Number)
+	assert.Equal(3, page.Size)
+	assert.Equal(1, page.TotalPages)
+	assert.True(page.IsFirst())
+	assert.True(page.IsLast())
+	assert.False(page.HasNext())
+}
+
+func TestNewPageWithNilPageable(t *testing.T) {
+	page, err := NewPage([]int{1, 2}, nil, 2)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(2, page.Size)
+	assert.Equal(1, page.TotalPages)
+}
+
+func TestNewPageRetainsPageableAndSort(t *testing.T) {
+	pageable := NewSortedPageable(1, 3, SortBy(Desc, "name"))
+	page, _ := NewPage([]int{1, 2, 3}, pageable, 10)
+
+	assert := assert.New(t)
+	assert.Equal(pageable, page.Pageable)
+	assert.Equal(SortBy(Desc, "name"), page.Sort)
+}
+
+func TestPageJSONIncludesSort(t *testing.T) {
+	page, _ := NewPage([]int{1}, NewSortedPageable(0, 3, SortBy(Desc, "name")), 1)
+	encoded, err := json.Marshal(page)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.JSONEq(`{"content":[1],"number":0,"size":3,"totalPages":1,"totalElements":1,
+		"sort":{"orders":[{"property":"name","direction":"desc","ignoreCase":false,"nullHandling":"native"}]}}`, string(encoded))
+}
+
+func TestNumberOfElements(t *testing.T) {
+	page, _ := NewPage([]int{1, 2}, NewPageable(1, 3), 5)
+
+	assert.Equal(t, 2, page.NumberOfElements())
+}
+
+func TestNextPageable(t *testing.T) {
+	page, _ := NewPage([]int{1, 2, 3}, NewSortedPageable(1, 3, SortByProperties("name")), 10)
+
+	assert.Equal(t, NewSortedPageable(2, 3, SortByProperties("name")), page.NextPageable())
+}
+
+func TestNextPageableOnLastPage(t *testing.T) {
+	page, _ := NewPage([]int{1}, NewPageable(3, 3), 10)
+
+	assert.Nil(t, page.NextPageable())
+}
+
+func TestPreviousPageable(t *testing.T) {
+	page, _ := NewPage([]int{1, 2, 3}, NewPageable(1, 3), 10)
+
+	assert.Equal(t, NewPageable(0, 3), page.PreviousPageable())
+}
+
+func TestPreviousPageableOnFirstPage(t *testing.T) {
+	page, _ := NewPage([]int{1, 2, 3}, NewPageable(0, 3), 10)
+
+	assert.Nil(t, page.PreviousPageable())
+}
+
+func TestLastPageable(t *testing.T) {
+	page, _ := NewPage([]int{1, 2, 3}, NewSortedPageable(0, 3, SortByProperties("name")), 10)
+
+	assert.Equal(t, NewSortedPageable(3, 3, SortByProperties("name")), page.LastPageable())
+}
+
+func TestNavigationOfDecodedPage(t *testing.T) {
+	var page Page
+	err := json.Unmarshal([]byte(`{"content":[1,2,3],"number":2,"size":3,"totalPages":10,"totalElements":30}`), &page)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(NewSortedPageable(3, 3, nil), page.NextPageable())
+	assert.Equal(NewSortedPageable(1, 3, nil), page.PreviousPageable())
+	assert.Equal(NewSortedPageable(9, 3, nil), page.LastPageable())
+}
+
+func TestLastPageableOnUnpaged(t *testing.T) {
+	page, _ := NewPage([]int{1, 2, 3}, nil, 3)
+
+	assert.Equal(t, Unpaged(), page.LastPageable())
+}
+
+func TestNewPageWithZeroSize(t *testing.T) {
+	page, err := NewPage([]int{}, NewPageable(0, 0), 10)
+
+	assert := assert.New(t)
+	assert.Nil(page)
+	assert.Equal(ErrInvalidSize, err)
+}
+
+func TestNewPageWithNegativePage(t *testing.T) {
+	_, err := NewPage([]int{}, NewPageable(-1, 10), 10)
+
+	assert.Equal(t, ErrInvalidPage, err)
+}
+
+func TestNewPageWithNegativeTotal(t *testing.T) {
+	_, err := NewPage([]int{}, NewPageable(0, 10), -1)
+
+	assert.Equal(t, ErrInvalidTotal, err)
+}
+
+func TestNewPageWithContentLongerThanSize(t *testing.T) {
+	_, err := NewPage([]int{1, 2, 3}, NewPageable(0, 2), 10)
+
+	assert.Equal(t, ErrContentTooLarge, err)
+}
+
+func TestNewPageWithNilContent(t *testing.T) {
+	_, err := NewPage(nil, NewPageable(0, 2), 10)
+
+	assert.Equal(t, ErrInvalidContent, err)
+}
+
+func TestEmptyResultHasNoPages(t *testing.T) {
+	page, err := NewPage([]int{}, NewPageable(0, 10), 0)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(0, page.TotalPages)
+	assert.True(page.IsFirst())
+	assert.True(page.IsLast())
+	assert.False(page.HasNext())
+	assert.False(page.HasPrevious())
+	assert.False(page.HasContent())
+}
+
+func TestEmptyUnpagedResult(t *testing.T) {
+	page, err := NewPage([]int{}, Unpaged(), 0)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(0, page.TotalPages)
+	assert.True(page.IsLast())
+}
+
+func TestPageValidate(t *testing.T) {
+	page, _ := NewPage([]int{1, 2}, NewPageable(1, 2), 5)
+
+	assert := assert.New(t)
+	assert.Nil(page.Validate())
+	assert.Equal(ErrInvalidTotalPages, (&Page{Content: []int{}, Size: 10, TotalPages: 2, TotalElements: 5}).Validate())
+	assert.Equal(ErrContentTooLarge, (&Page{Content: []int{1, 2}, Size: 1, TotalPages: 2, TotalElements: 2}).Validate())
+	assert.Equal(ErrInvalidTotal, (&Page{Content: []int{}, Size: 1, TotalElements: -2}).Validate())
+	assert.Equal(ErrInvalidContent, (&Page{Size: 1}).Validate())
+}
+
+func TestNewPageWithLargeTotal(t *testing.T) {
+	page, err := NewPage([]int{1, 2, 3}, NewPageable(0, 3), 6000000000)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(6000000000), page.TotalElements)
+	assert.Equal(2000000000, page.TotalPages)
+}
+
+func TestNewCappedPageBelowLimit(t *testing.T) {
+	page, err := NewCappedPage([]int{1, 2}, NewPageable(0, 10), 2, 10000)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(2), page.TotalElements)
+	assert.False(page.TotalIsLowerBound)
+	assert.Equal("2", page.FormatTotal())
+}
+
+func TestNewCappedPageReachingLimit(t *testing.T) {
+	page, err := NewCappedPage([]int{1, 2, 3}, NewPageable(0, 3), 10000, 10000)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(10000), page.TotalElements)
+	assert.True(page.TotalIsLowerBound)
+	assert.Equal("10000+", page.FormatTotal())
+}
+
+func TestLowerBoundPageMayHaveNextPastCountedPages(t *testing.T) {
+	full, _ := NewLowerBoundPage([]int{1, 2, 3}, NewPageable(3, 3), 12)
+	partial, _ := NewLowerBoundPage([]int{1}, NewPageable(4, 3), 12)
+
+	assert := assert.New(t)
+	assert.True(full.HasNext())
+	assert.False(full.IsLast())
+	assert.False(partial.HasNext())
+	assert.True(partial.IsLast())
+}
+
+func TestOffsetBasedPageNavigation(t *testing.T) {
+	page, _ := NewPage([]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, NewOffsetPageable(17, 10, nil), 26)
+	first, _ := NewPage([]int{1, 2, 3}, NewOffsetPageable(5, 3, nil), 26)
+
+	assert := assert.New(t)
+	assert.False(page.HasNext())
+	assert.True(page.IsLast())
+	assert.True(page.HasPrevious())
+	assert.True(first.HasNext())
+	assert.True(first.HasPrevious())
+	assert.False(first.IsFirst())
+	assert.Equal(int64(8), first.NextPageable().Offset())
+	assert.Equal(int64(2), first.PreviousPageable().Offset())
+}
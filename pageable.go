@@ -1,10 +1,29 @@
 package data
 
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidPage is returned when a negative page number is provided
+// ErrInvalidSize is returned when a page size lower than 1 is provided
+// ErrOffsetOverflow is returned when the offset of a page overflows an int64
+var (
+	ErrInvalidPage    = errors.New("Invalid page provided: expected a value greater or equal than 0")
+	ErrInvalidSize    = errors.New("Invalid size provided: expected a value greater or equal than 1")
+	ErrOffsetOverflow = errors.New("Invalid page provided: its offset overflows a 64 bit integer")
+)
+
 // Pageable is the struct holding the page requests
 type Pageable struct {
 	Page int
 	Size int
 	Sort *Sort
+
+	unpaged bool
+	// offset is the start of offset based requests, which may not be aligned to the page size
+	offset      int64
+	offsetBased bool
 }
 
 // NewPageable creates a new Pageable instance for provided page and size
@@ -14,17 +33,157 @@ func NewPageable(page, size int) *Pageable {
 
 // NewSortedPageable creats a new Pageable instance for provided page, size and Sort object
 func NewSortedPageable(page, size int, sort *Sort) *Pageable {
-	return &Pageable{page, size, sort}
+	return &Pageable{Page: page, Size: size, Sort: sort}
+}
+
+// NewValidPageable creates a new Pageable instance for provided page and size, returning an error for invalid values
+func NewValidPageable(page, size int) (*Pageable, error) {
+	return NewValidSortedPageable(page, size, EmptySort())
+}
+
+// NewValidSortedPageable creates a new Pageable instance for provided page, size and Sort object, returning an error
+// for invalid values
+func NewValidSortedPageable(page, size int, sort *Sort) (*Pageable, error) {
+	pageable := NewSortedPageable(page, size, sort)
+	if err := pageable.Validate(); err != nil {
+		return nil, err
+	}
+	return pageable, nil
+}
+
+// PageableForOffset creates a new Pageable instance for the page of provided size containing the element at given offset
+func PageableForOffset(offset int64, size int, sort *Sort) *Pageable {
+	if offset <= 0 || size <= 0 {
+		return &Pageable{Page: 0, Size: size, Sort: sort}
+	}
+	return &Pageable{Page: toInt(offset / int64(size)), Size: size, Sort: sort}
+}
+
+// NewOffsetPageable creates a new Pageable instance for provided number of elements starting at given offset,
+// which is not required to be a multiple of size. Its Page is the one containing the offset.
+func NewOffsetPageable(offset int64, size int, sort *Sort) *Pageable {
+	pageable := PageableForOffset(offset, size, sort)
+	if offset > 0 {
+		pageable.offset = offset
+	}
+	pageable.offsetBased = true
+	return pageable
+}
+
+// Unpaged creates a new Pageable instance requesting all the elements at once, without sorting
+func Unpaged() *Pageable {
+	return UnpagedWithSort(Unsorted())
+}
+
+// UnpagedWithSort creates a new Pageable instance requesting all the elements at once, sorted by provided Sort object
+func UnpagedWithSort(sort *Sort) *Pageable {
+	return &Pageable{Sort: sort, unpaged: true}
+}
+
+// Validate check if the page and size values are valid, as they may have been decoded from an untrusted source.
+// Unpaged requests are always valid.
+func (p *Pageable) Validate() error {
+	if p.IsUnpaged() {
+		return nil
+	}
+	if p.Page < 0 {
+		return ErrInvalidPage
+	}
+	if p.Size <= 0 {
+		return ErrInvalidSize
+	}
+	return nil
+}
+
+// IsPaged check if the Pageable requests a single page. A nil Pageable is unpaged.
+func (p *Pageable) IsPaged() bool {
+	return p != nil && !p.unpaged
+}
+
+// IsUnpaged check if the Pageable requests all the elements at once. A nil Pageable is unpaged.
+func (p *Pageable) IsUnpaged() bool {
+	return !p.IsPaged()
+}
+
+// SortOrUnsorted returns the Pageable Sort object, or Unsorted when the Pageable or its Sort are nil
+func (p *Pageable) SortOrUnsorted() *Sort {
+	if p == nil || p.Sort == nil {
+		return Unsorted()
+	}
+	return p.Sort
+}
+
+// WithTieBreaker creates a new Pageable whose Sort ends with the provided unique property
+func (p *Pageable) WithTieBreaker(property string) *Pageable {
+	if p.IsUnpaged() {
+		return UnpagedWithSort(p.SortOrUnsorted().WithTieBreaker(property))
+	}
+	pageable := *p
+	pageable.Sort = p.Sort.WithTieBreaker(property)
+	return &pageable
 }
 
-// Offset returns the offset from start
-func (p *Pageable) Offset() int {
-	return p.Page * p.Size
+// Offset returns the offset from start, which is always 0 for unpaged requests. The offset saturates at
+// math.MaxInt64 instead of overflowing: use CheckedOffset to detect it.
+func (p *Pageable) Offset() int64 {
+	offset, err := p.CheckedOffset()
+	if err != nil {
+		return math.MaxInt64
+	}
+	return offset
+}
+
+// CheckedOffset returns the offset from start, or ErrOffsetOverflow when it cannot be represented by an int64
+func (p *Pageable) CheckedOffset() (int64, error) {
+	if p.IsUnpaged() {
+		return 0, nil
+	}
+	if p.offsetBased {
+		return p.offset, nil
+	}
+	if p.Page <= 0 || p.Size <= 0 {
+		return 0, nil
+	}
+	if int64(p.Page) > math.MaxInt64/int64(p.Size) {
+		return 0, ErrOffsetOverflow
+	}
+	return int64(p.Page) * int64(p.Size), nil
+}
+
+// WithSize creates a new Pageable with the provided page size for the page containing the first element of this one
+func (p *Pageable) WithSize(size int) *Pageable {
+	if p.IsUnpaged() {
+		return &Pageable{Page: 0, Size: size, Sort: p.SortOrUnsorted()}
+	}
+	return PageableForOffset(p.Offset(), size, p.Sort)
+}
+
+// Last creates a new Pageable for the last page given the total number of elements. Unpaged requests are returned
+// unchanged.
+func (p *Pageable) Last(total int64) *Pageable {
+	if p.IsUnpaged() {
+		return p.orUnpaged()
+	}
+	return &Pageable{Page: lastPage(total, p.Size), Size: p.Size, Sort: p.Sort}
+}
+
+// Clamp returns the last page given the total number of elements if this one is past it, or the Pageable itself
+func (p *Pageable) Clamp(total int64) *Pageable {
+	if p.IsPaged() && p.offsetBased && p.offset > 0 && p.offset >= total {
+		return p.Last(total)
+	}
+	if p.IsPaged() && !p.offsetBased && p.Page > lastPage(total, p.Size) {
+		return p.Last(total)
+	}
+	return p.orUnpaged()
 }
 
 // HasPrevious check if the Pageable has previous page or not
 func (p *Pageable) HasPrevious() bool {
-	return p.Page > 0
+	if p.IsPaged() && p.offsetBased {
+		return p.offset > 0
+	}
+	return p.IsPaged() && p.Page > 0
 }
 
 // PreviousOrFirst will return a new Pageable for the previous page or the first page if no previous page exists
@@ -35,20 +194,64 @@ func (p *Pageable) PreviousOrFirst() *Pageable {
 	return p.First()
 }
 
-// Next creates a new Pageable for the next result page
+// Next creates a new Pageable for the next result page. Unpaged requests have no next page and are returned unchanged.
 func (p *Pageable) Next() *Pageable {
-	return &Pageable{p.Page + 1, p.Size, p.Sort}
+	if p.IsUnpaged() {
+		return p.orUnpaged()
+	}
+	if p.offsetBased {
+		if p.offset > math.MaxInt64-int64(p.Size) {
+			return NewOffsetPageable(math.MaxInt64, p.Size, p.Sort)
+		}
+		return NewOffsetPageable(p.offset+int64(p.Size), p.Size, p.Sort)
+	}
+	return &Pageable{Page: p.Page + 1, Size: p.Size, Sort: p.Sort}
 }
 
 // Previous creates a new Pageable for the previous result page
 func (p *Pageable) Previous() *Pageable {
-	if p.Page == 0 {
-		return p
+	if !p.HasPrevious() {
+		return p.orUnpaged()
+	}
+	if p.offsetBased {
+		return NewOffsetPageable(p.offset-int64(p.Size), p.Size, p.Sort)
 	}
-	return &Pageable{p.Page - 1, p.Size, p.Sort}
+	return &Pageable{Page: p.Page - 1, Size: p.Size, Sort: p.Sort}
 }
 
 // First creates a new Pageable for the first result page
 func (p *Pageable) First() *Pageable {
-	return &Pageable{0, p.Size, p.Sort}
+	if p.IsUnpaged() {
+		return p.orUnpaged()
+	}
+	return &Pageable{Page: 0, Size: p.Size, Sort: p.Sort}
+}
+
+// IsOffsetBased check if the Pageable was created for an offset, which may not be aligned to the page size
+func (p *Pageable) IsOffsetBased() bool {
+	return p.IsPaged() && p.offsetBased
+}
+
+// orUnpaged returns the Pageable itself or Unpaged when nil
+func (p *Pageable) orUnpaged() *Pageable {
+	if p == nil {
+		return Unpaged()
+	}
+	return p
+}
+
+// lastPage returns the index of the last page of provided size, which is 0 for empty results
+func lastPage(total int64, size int) int {
+	if total <= 0 || size <= 0 {
+		return 0
+	}
+	return toInt((total - 1) / int64(size))
+}
+
+// toInt converts the provided value to int, saturating at math.MaxInt on 32 bit platforms
+func toInt(value int64) int {
+	if value > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(value)
 }
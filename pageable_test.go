@@ -1,6 +1,7 @@
 package data
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,7 +30,7 @@ func TestNewSortedPageableWillCreateSortedPageable(t *testing.T) {
 func TestPageableOffssetIsPageBySize(t *testing.T) {
 	pageable := NewPageable(3, 25)
 
-	assert.Equal(t, 75, pageable.Offset())
+	assert.Equal(t, int64(75), pageable.Offset())
 }
 
 func TestHasPreviousOnFirstPageShouldReturnFalse(t *testing.T) {
@@ -79,3 +80,185 @@ func TestFirstWillReturnFirstPage(t *testing.T) {
 
 	assert.Equal(t, 0, pageable.Page)
 }
+
+func TestWithTieBreakerWillAppendProperty(t *testing.T) {
+	pageable := NewSortedPageable(2, 10, SortBy(Desc, "status")).WithTieBreaker("id")
+
+	assert := assert.New(t)
+	assert.Equal(2, pageable.Page)
+	assert.Equal(10, pageable.Size)
+	assert.Equal(SortBy(Desc, "status", "id"), pageable.Sort)
+}
+
+func TestUnpagedIsNotPaged(t *testing.T) {
+	pageable := Unpaged()
+
+	assert := assert.New(t)
+	assert.False(pageable.IsPaged())
+	assert.True(pageable.IsUnpaged())
+	assert.True(pageable.Sort.IsUnsorted())
+	assert.Equal(int64(0), pageable.Offset())
+	assert.False(pageable.HasPrevious())
+	assert.Equal(pageable, pageable.Next())
+	assert.Equal(pageable, pageable.Previous())
+	assert.Equal(pageable, pageable.First())
+}
+
+func TestNilPageableIsUnpaged(t *testing.T) {
+	var pageable *Pageable
+
+	assert := assert.New(t)
+	assert.True(pageable.IsUnpaged())
+	assert.Equal(int64(0), pageable.Offset())
+	assert.False(pageable.HasPrevious())
+	assert.Equal(Unpaged(), pageable.Next())
+	assert.Equal(Unsorted(), pageable.SortOrUnsorted())
+}
+
+func TestUnpagedWithSortKeepsSort(t *testing.T) {
+	pageable := UnpagedWithSort(SortByProperties("name")).WithTieBreaker("id")
+
+	assert := assert.New(t)
+	assert.True(pageable.IsUnpaged())
+	assert.Equal(SortByProperties("name", "id"), pageable.Sort)
+}
+
+func TestPageableIsPaged(t *testing.T) {
+	assert.True(t, NewPageable(0, 10).IsPaged())
+}
+
+func TestPageableForOffsetReturnsContainingPage(t *testing.T) {
+	pageable := PageableForOffset(57, 25, SortByProperties("name"))
+
+	assert := assert.New(t)
+	assert.Equal(2, pageable.Page)
+	assert.Equal(25, pageable.Size)
+	assert.Equal(SortByProperties("name"), pageable.Sort)
+}
+
+func TestPageableForOffsetWithLargeOffset(t *testing.T) {
+	pageable := PageableForOffset(math.MaxInt64, 1000, nil)
+
+	assert.Equal(t, int(math.MaxInt64/1000), pageable.Page)
+}
+
+func TestWithSizeKeepsFirstElementVisible(t *testing.T) {
+	pageable := NewPageable(3, 10).WithSize(25)
+
+	assert := assert.New(t)
+	assert.Equal(1, pageable.Page)
+	assert.Equal(25, pageable.Size)
+}
+
+func TestWithSizeOnUnpaged(t *testing.T) {
+	pageable := Unpaged().WithSize(25)
+
+	assert := assert.New(t)
+	assert.True(pageable.IsPaged())
+	assert.Equal(0, pageable.Page)
+	assert.Equal(25, pageable.Size)
+}
+
+func TestWithSizeDoesNotOverflow(t *testing.T) {
+	pageable := NewPageable(math.MaxInt, math.MaxInt).WithSize(math.MaxInt)
+
+	assert.Equal(t, int(math.MaxInt64/math.MaxInt), pageable.Page)
+}
+
+func TestLastReturnsLastPage(t *testing.T) {
+	assert.Equal(t, 4, NewPageable(0, 10).Last(45).Page)
+	assert.Equal(t, 3, NewPageable(0, 10).Last(40).Page)
+	assert.Equal(t, 0, NewPageable(2, 10).Last(0).Page)
+}
+
+func TestLastWithLargeTotal(t *testing.T) {
+	assert.Equal(t, int(math.MaxInt64/2), NewPageable(0, 2).Last(math.MaxInt64).Page)
+}
+
+func TestClampReturnsLastPageWhenPastTheEnd(t *testing.T) {
+	assert.Equal(t, 4, NewPageable(9, 10).Clamp(45).Page)
+}
+
+func TestClampKeepsPageInRange(t *testing.T) {
+	pageable := NewPageable(2, 10)
+
+	assert.Equal(t, pageable, pageable.Clamp(45))
+}
+
+func TestNewValidPageable(t *testing.T) {
+	pageable, err := NewValidPageable(2, 10)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(NewPageable(2, 10), pageable)
+}
+
+func TestNewValidPageableWithInvalidPage(t *testing.T) {
+	pageable, err := NewValidPageable(-1, 10)
+
+	assert := assert.New(t)
+	assert.Nil(pageable)
+	assert.Equal(ErrInvalidPage, err)
+}
+
+func TestNewValidSortedPageableWithInvalidSize(t *testing.T) {
+	pageable, err := NewValidSortedPageable(0, 0, SortByProperties("name"))
+
+	assert := assert.New(t)
+	assert.Nil(pageable)
+	assert.Equal(ErrInvalidSize, err)
+}
+
+func TestValidateOnDecodedPageable(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(ErrInvalidSize, (&Pageable{Page: 1}).Validate())
+	assert.Equal(ErrInvalidPage, (&Pageable{Page: -3, Size: 10}).Validate())
+	assert.Nil((&Pageable{Page: 1, Size: 10}).Validate())
+	assert.Nil(Unpaged().Validate())
+}
+
+func TestCheckedOffset(t *testing.T) {
+	offset, err := NewPageable(3, 25).CheckedOffset()
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(75), offset)
+}
+
+func TestCheckedOffsetOverflow(t *testing.T) {
+	pageable := NewPageable(math.MaxInt64/10+1, 10)
+	_, err := pageable.CheckedOffset()
+
+	assert := assert.New(t)
+	assert.Equal(ErrOffsetOverflow, err)
+	assert.Equal(int64(math.MaxInt64), pageable.Offset())
+}
+
+func TestNewOffsetPageable(t *testing.T) {
+	pageable := NewOffsetPageable(15, 10, SortByProperties("name"))
+
+	assert := assert.New(t)
+	assert.True(pageable.IsOffsetBased())
+	assert.Equal(1, pageable.Page)
+	assert.Equal(10, pageable.Size)
+	assert.Equal(int64(15), pageable.Offset())
+	assert.True(pageable.HasPrevious())
+}
+
+func TestOffsetPageableNavigation(t *testing.T) {
+	pageable := NewOffsetPageable(15, 10, nil)
+
+	assert := assert.New(t)
+	assert.Equal(int64(25), pageable.Next().Offset())
+	assert.Equal(int64(5), pageable.Previous().Offset())
+	assert.Equal(int64(0), pageable.Previous().Previous().Offset())
+	assert.False(pageable.Previous().Previous().HasPrevious())
+	assert.Equal(int64(0), pageable.First().Offset())
+	assert.Equal(int64(15), pageable.WithTieBreaker("id").Offset())
+}
+
+func TestOffsetPageableClamp(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(int64(20), NewOffsetPageable(26, 10, nil).Clamp(26).Offset())
+	assert.Equal(int64(25), NewOffsetPageable(25, 10, nil).Clamp(26).Offset())
+}
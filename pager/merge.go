@@ -0,0 +1,295 @@
+package pager
+
+import (
+	"container/heap"
+	"context"
+	"encoding/base64"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// ErrInvalidToken is returned when a merge token cannot be decoded or was produced for a different number of sources
+// ErrNoSources is returned when a Merger has no sources
+var (
+	ErrInvalidToken = errors.New("Invalid token provided: it was not produced by this merger")
+	ErrNoSources    = errors.New("Invalid merger: at least a source is required")
+)
+
+// unpagedFetchSize is the default size of the pages fetched from the sources for unpaged requests
+const unpagedFetchSize = 1000
+
+// MergeOptions contains the settings of a Merger
+type MergeOptions struct {
+	// FetchSize is the size of the pages fetched from every source, the requested size divided by the number of
+	// sources when 0
+	FetchSize int
+}
+
+// MergePage is a page of the merged items
+type MergePage[T any] struct {
+	Content []T
+	Size    int
+	// Token continues the merge right after the last item, empty when every source is exhausted
+	Token   string
+	HasNext bool
+	// TotalElements is the sum of the totals of the sources
+	TotalElements     int64
+	TotalIsLowerBound bool
+}
+
+// Merger merges the items of several sources sorted by the same Sort into globally sorted pages
+type Merger[T any] struct {
+	sources []data.PageFetcher
+	compare func(a, b T) int
+	options MergeOptions
+}
+
+// NewMerger creates a new Merger for the sources, whose page contents must be []T sorted consistently with the
+// compare function
+func NewMerger[T any](sources []data.PageFetcher, compare func(a, b T) int, options MergeOptions) *Merger[T] {
+	return &Merger[T]{sources: sources, compare: compare, options: options}
+}
+
+// NewSortMerger creates a new Merger for the sources comparing the items with data.Comparator, according to the
+// Sort that will be requested to the sources
+func NewSortMerger[T any](sources []data.PageFetcher, sort *data.Sort, options MergeOptions) (*Merger[T], error) {
+	compare, err := data.Comparator[T](sort)
+	if err != nil {
+		return nil, err
+	}
+	return NewMerger(sources, compare, options), nil
+}
+
+// Fetch returns up to size merged items, requesting the sources sorted by the provided Sort, starting from the
+// token or from the first items when the token is empty. Every source is only fetched as much as required.
+func (m *Merger[T]) Fetch(ctx context.Context, token string, size int, sort *data.Sort) (*MergePage[T], error) {
+	if size <= 0 {
+		return nil, data.ErrInvalidSize
+	}
+	states, err := m.decodeToken(token)
+	if err != nil {
+		return nil, err
+	}
+	content, err := m.merge(ctx, states, 0, size, sort)
+	if err != nil {
+		return nil, err
+	}
+	page := &MergePage[T]{Content: content, Size: size}
+	page.TotalElements, page.TotalIsLowerBound = totals(states)
+	for _, state := range states {
+		page.HasNext = page.HasNext || !state.done()
+	}
+	if page.HasNext {
+		page.Token = encodeToken(states)
+	}
+	return page, nil
+}
+
+// FetchPage returns the requested page of the merged items, merging the sources from their first items. Requests
+// far from the first page are expensive, as every preceding item is merged too: prefer Fetch with tokens.
+func (m *Merger[T]) FetchPage(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+	if err := pageable.Validate(); err != nil {
+		return nil, err
+	}
+	states, err := m.decodeToken("")
+	if err != nil {
+		return nil, err
+	}
+	skip, size := int64(0), math.MaxInt
+	if pageable.IsPaged() {
+		skip, size = pageable.Offset(), pageable.Size
+	}
+	content, err := m.merge(ctx, states, skip, size, pageable.SortOrUnsorted())
+	if err != nil {
+		return nil, err
+	}
+	total, lowerBound := totals(states)
+	if pageable.IsUnpaged() {
+		total = int64(len(content))
+	}
+	page, err := data.NewPage(content, pageable, total)
+	if err != nil {
+		return nil, err
+	}
+	page.TotalIsLowerBound = lowerBound
+	return page, nil
+}
+
+// source is the merge state of a source
+type source[T any] struct {
+	index      int
+	offset     int64
+	buffer     []T
+	last       bool
+	total      int64
+	lowerBound bool
+}
+
+// done check if every item of the source has been merged
+func (s *source[T]) done() bool {
+	return s.last && len(s.buffer) == 0
+}
+
+// merge skips the first items and returns the next size ones, advancing the states
+func (m *Merger[T]) merge(ctx context.Context, states []*source[T], skip int64, size int, sort *data.Sort) ([]T, error) {
+	fetchSize := m.options.FetchSize
+	switch {
+	case fetchSize > 0:
+	case size == math.MaxInt:
+		fetchSize = unpagedFetchSize
+	default:
+		fetchSize = (size-1)/len(states) + 1
+	}
+	queue := &mergeQueue[T]{compare: m.compare}
+	pending := states
+	content := []T{}
+	for len(content) < size {
+		for _, state := range pending {
+			if err := m.fill(ctx, state, fetchSize, sort); err != nil {
+				return nil, err
+			}
+			if len(state.buffer) > 0 {
+				heap.Push(queue, state)
+			}
+		}
+		pending = nil
+		if queue.Len() == 0 {
+			break
+		}
+		state := heap.Pop(queue).(*source[T])
+		item := state.buffer[0]
+		state.buffer = state.buffer[1:]
+		state.offset++
+		if skip > 0 {
+			skip--
+		} else {
+			content = append(content, item)
+		}
+		if len(state.buffer) > 0 {
+			heap.Push(queue, state)
+		} else if !state.last {
+			pending = append(pending, state)
+		}
+	}
+	return content, nil
+}
+
+// fill fetches the items of the source following its offset, when its buffer is empty
+func (m *Merger[T]) fill(ctx context.Context, state *source[T], size int, sort *data.Sort) error {
+	if len(state.buffer) > 0 || state.last {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	page, err := m.sources[state.index].FetchPage(ctx, data.NewOffsetPageable(state.offset, size, sort))
+	if err != nil {
+		return err
+	}
+	if page == nil {
+		return data.ErrInvalidContent
+	}
+	items, ok := page.Content.([]T)
+	if !ok {
+		return data.ErrInvalidContent
+	}
+	state.buffer = items
+	state.last = len(items) == 0 || !page.HasNext()
+	state.total, state.lowerBound = page.TotalElements, page.TotalIsLowerBound
+	return nil
+}
+
+// totals returns the sum of the totals of the sources, which is a lower bound when a source total is
+func totals[T any](states []*source[T]) (int64, bool) {
+	total, lowerBound := int64(0), false
+	for _, state := range states {
+		if total > math.MaxInt64-state.total {
+			total = math.MaxInt64
+		} else {
+			total += state.total
+		}
+		lowerBound = lowerBound || state.lowerBound
+	}
+	return total, lowerBound
+}
+
+// encodeToken encodes the offset of every source, followed by ! when the source is exhausted
+func encodeToken[T any](states []*source[T]) string {
+	offsets := make([]string, len(states))
+	for i, state := range states {
+		offsets[i] = strconv.FormatInt(state.offset, 10)
+		if state.done() {
+			offsets[i] += "!"
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(offsets, ",")))
+}
+
+// decodeToken creates the states of the sources for the token, starting from the first items when empty
+func (m *Merger[T]) decodeToken(token string) ([]*source[T], error) {
+	if len(m.sources) == 0 {
+		return nil, ErrNoSources
+	}
+	states := make([]*source[T], len(m.sources))
+	for i := range states {
+		states[i] = &source[T]{index: i}
+	}
+	if token == "" {
+		return states, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	offsets := strings.Split(string(decoded), ",")
+	if len(offsets) != len(states) {
+		return nil, ErrInvalidToken
+	}
+	for i, value := range offsets {
+		exhausted := strings.HasSuffix(value, "!")
+		offset, err := strconv.ParseInt(strings.TrimSuffix(value, "!"), 10, 64)
+		if err != nil || offset < 0 {
+			return nil, ErrInvalidToken
+		}
+		states[i].offset = offset
+		if exhausted {
+			states[i].last, states[i].total = true, offset
+		}
+	}
+	return states, nil
+}
+
+// mergeQueue is a heap of the sources ordered by their first buffered item, then by their index
+type mergeQueue[T any] struct {
+	states  []*source[T]
+	compare func(a, b T) int
+}
+
+func (q *mergeQueue[T]) Len() int {
+	return len(q.states)
+}
+
+func (q *mergeQueue[T]) Less(i, j int) bool {
+	if result := q.compare(q.states[i].buffer[0], q.states[j].buffer[0]); result != 0 {
+		return result < 0
+	}
+	return q.states[i].index < q.states[j].index
+}
+
+func (q *mergeQueue[T]) Swap(i, j int) {
+	q.states[i], q.states[j] = q.states[j], q.states[i]
+}
+
+func (q *mergeQueue[T]) Push(x any) {
+	q.states = append(q.states, x.(*source[T]))
+}
+
+func (q *mergeQueue[T]) Pop() any {
+	last := q.states[len(q.states)-1]
+	q.states = q.states[:len(q.states)-1]
+	return last
+}
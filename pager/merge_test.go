@@ -0,0 +1,130 @@
+package pager
+
+import (
+	"cmp"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func shards() []*upstream {
+	return []*upstream{
+		{items: []int{1, 4, 7, 10, 13}},
+		{items: []int{2, 5, 8}},
+		{items: []int{3, 6, 9, 12, 15, 18}},
+	}
+}
+
+func fetchers(sources []*upstream) []data.PageFetcher {
+	result := make([]data.PageFetcher, len(sources))
+	for i, source := range sources {
+		result[i] = source
+	}
+	return result
+}
+
+func TestMergerWalksGlobalPages(t *testing.T) {
+	sources := shards()
+	merger := NewMerger(fetchers(sources), cmp.Compare[int], MergeOptions{})
+	ctx := context.Background()
+
+	assert := assert.New(t)
+	var merged []int
+	token := ""
+	for pages := 0; ; pages++ {
+		page, err := merger.Fetch(ctx, token, 4, nil)
+		assert.Nil(err)
+		assert.Equal(int64(14), page.TotalElements)
+		merged = append(merged, page.Content...)
+		if !page.HasNext {
+			assert.Empty(page.Token)
+			assert.Equal(3, pages)
+			break
+		}
+		token = page.Token
+	}
+	assert.Equal([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 13, 15, 18}, merged)
+}
+
+func TestMergerFetchesOnlyRequiredItems(t *testing.T) {
+	sources := []*upstream{{items: []int{1, 2, 3, 4, 5, 6}}, {items: []int{10, 11, 12}}}
+	merger := NewMerger(fetchers(sources), cmp.Compare[int], MergeOptions{FetchSize: 2})
+
+	page, err := merger.Fetch(context.Background(), "", 4, nil)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]int{1, 2, 3, 4}, page.Content)
+	assert.Equal([]int{0, 1}, sources[0].requests)
+	assert.Equal([]int{0}, sources[1].requests)
+}
+
+func TestMergerSkipsExhaustedSources(t *testing.T) {
+	sources := []*upstream{{items: []int{1}}, {items: []int{2, 3, 4}}}
+	merger := NewMerger(fetchers(sources), cmp.Compare[int], MergeOptions{})
+
+	first, _ := merger.Fetch(context.Background(), "", 2, nil)
+	second, err := merger.Fetch(context.Background(), first.Token, 2, nil)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]int{3, 4}, second.Content)
+	assert.Equal(int64(4), second.TotalElements)
+	assert.Equal(1, len(sources[0].requests))
+}
+
+func TestMergerFetchPage(t *testing.T) {
+	merger := NewMerger(fetchers(shards()), cmp.Compare[int], MergeOptions{})
+
+	page, err := merger.FetchPage(context.Background(), data.NewPageable(2, 4))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]int{9, 10, 12, 13}, page.Content)
+	assert.Equal(2, page.Number)
+	assert.Equal(4, page.TotalPages)
+
+	page, err = merger.FetchPage(context.Background(), data.Unpaged())
+	assert.Nil(err)
+	assert.Equal(14, page.NumberOfElements())
+}
+
+func TestMergerWithInvalidToken(t *testing.T) {
+	merger := NewMerger(fetchers(shards()), cmp.Compare[int], MergeOptions{})
+	ctx := context.Background()
+
+	assert := assert.New(t)
+	_, err := merger.Fetch(ctx, "%%%", 4, nil)
+	assert.Equal(ErrInvalidToken, err)
+	_, err = merger.Fetch(ctx, encodeToken([]*source[int]{{}}), 4, nil)
+	assert.Equal(ErrInvalidToken, err)
+	_, err = NewMerger(nil, cmp.Compare[int], MergeOptions{}).Fetch(ctx, "", 4, nil)
+	assert.Equal(ErrNoSources, err)
+}
+
+func TestSortMerger(t *testing.T) {
+	type event struct {
+		ID int `json:"id"`
+	}
+	byID := data.SortBy(data.Desc, "id")
+	source := func(ids ...int) data.PageFetcher {
+		return data.PageFetcherFunc(func(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+			events := []event{}
+			for _, id := range ids[min(int(pageable.Offset()), len(ids)):] {
+				events = append(events, event{id})
+			}
+			return data.NewPage(events[:min(len(events), pageable.Size)], pageable, int64(len(ids)))
+		})
+	}
+	merger, err := NewSortMerger[event]([]data.PageFetcher{source(9, 4, 1), source(8, 7, 2)}, byID, MergeOptions{})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	page, err := merger.Fetch(context.Background(), "", 4, byID)
+	assert.Nil(err)
+	assert.Equal([]event{{9}, {8}, {7}, {4}}, page.Content)
+	_, err = NewSortMerger[event](nil, data.SortByProperties("missing"), MergeOptions{})
+	assert.Equal(data.ErrUnknownProperty, err)
+}
@@ -0,0 +1,166 @@
+// Package pager adapts data sources to serve pages they cannot produce natively
+package pager
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// ErrInvalidUpstreamPage is returned when the upstream source returns no page or a page larger than its size
+var ErrInvalidUpstreamPage = errors.New("Invalid upstream page: expected at most size elements")
+
+// RechunkOptions contains the settings of a Rechunker
+type RechunkOptions struct {
+	// CacheSize is the maximum number of upstream pages kept in memory, no caching when 0
+	CacheSize int
+	// TTL is the time after which a cached upstream page is fetched again, never when 0
+	TTL time.Duration
+}
+
+// Rechunker is a data.PageFetcher serving any Pageable from an upstream source only able to serve pages of a fixed
+// size, by stitching the upstream pages covering the requested elements
+type Rechunker struct {
+	upstream data.PageFetcher
+	size     int
+	options  RechunkOptions
+
+	mu    sync.Mutex
+	pages map[string]*list.Element
+	lru   *list.List
+}
+
+// cached is an upstream page held by the cache
+type cached struct {
+	key     string
+	page    *data.Page
+	fetched time.Time
+}
+
+// NewRechunker creates a new Rechunker for the upstream source serving pages of the provided size
+func NewRechunker(upstream data.PageFetcher, size int, options RechunkOptions) *Rechunker {
+	return &Rechunker{upstream: upstream, size: size, options: options, pages: map[string]*list.Element{}, lru: list.New()}
+}
+
+// FetchPage loads the minimal set of upstream pages holding the requested elements and builds the requested page.
+// Unpaged requests load every upstream page. Totals are the ones of the last upstream page used.
+func (r *Rechunker) FetchPage(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+	if err := pageable.Validate(); err != nil {
+		return nil, err
+	}
+	if r.size <= 0 {
+		return nil, data.ErrInvalidSize
+	}
+	sort := pageable.SortOrUnsorted()
+	start, end := int64(0), int64(-1)
+	if pageable.IsPaged() {
+		start = pageable.Offset()
+		end = start + int64(pageable.Size)
+	}
+	var content reflect.Value
+	var last *data.Page
+	for number := start / int64(r.size); end < 0 || number*int64(r.size) < end; number++ {
+		page, err := r.upstreamPage(ctx, data.PageableForOffset(number*int64(r.size), r.size, sort))
+		if err != nil {
+			return nil, err
+		}
+		items := reflect.ValueOf(page.Content)
+		if !content.IsValid() {
+			content = reflect.MakeSlice(items.Type(), 0, items.Len())
+		}
+		from := start - number*int64(r.size)
+		if from < 0 {
+			from = 0
+		}
+		to := int64(items.Len())
+		if end >= 0 && end-number*int64(r.size) < to {
+			to = end - number*int64(r.size)
+		}
+		if from < to {
+			content = reflect.AppendSlice(content, items.Slice(int(from), int(to)))
+		}
+		last = page
+		if items.Len() < r.size || !page.HasNext() {
+			break
+		}
+	}
+	result, err := data.NewPage(content.Interface(), pageable, last.TotalElements)
+	if err != nil {
+		return nil, err
+	}
+	result.TotalIsLowerBound = last.TotalIsLowerBound
+	result.TotalIsEstimated = last.TotalIsEstimated
+	return result, nil
+}
+
+// Invalidate removes every upstream page from the cache
+func (r *Rechunker) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pages = map[string]*list.Element{}
+	r.lru.Init()
+}
+
+// upstreamPage returns the requested upstream page, from the cache when present and fresh
+func (r *Rechunker) upstreamPage(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+	key := cacheKey(pageable)
+	if page := r.lookup(key); page != nil {
+		return page, nil
+	}
+	page, err := r.upstream.FetchPage(ctx, pageable)
+	if err != nil {
+		return nil, err
+	}
+	if page == nil || page.Validate() != nil || page.NumberOfElements() > r.size {
+		return nil, ErrInvalidUpstreamPage
+	}
+	r.store(key, page)
+	return page, nil
+}
+
+func (r *Rechunker) lookup(key string) *data.Page {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	element, ok := r.pages[key]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*cached)
+	if r.options.TTL > 0 && time.Since(entry.fetched) > r.options.TTL {
+		r.lru.Remove(element)
+		delete(r.pages, key)
+		return nil
+	}
+	r.lru.MoveToFront(element)
+	return entry.page
+}
+
+func (r *Rechunker) store(key string, page *data.Page) {
+	if r.options.CacheSize <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if element, ok := r.pages[key]; ok {
+		r.lru.Remove(element)
+	}
+	r.pages[key] = r.lru.PushFront(&cached{key, page, time.Now()})
+	for r.lru.Len() > r.options.CacheSize {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.pages, oldest.Value.(*cached).key)
+	}
+}
+
+// cacheKey identifies an upstream page by its number and sort
+func cacheKey(pageable *data.Pageable) string {
+	sort, _ := json.Marshal(pageable.SortOrUnsorted().Orders)
+	return strconv.Itoa(pageable.Page) + string(sort)
+}
@@ -0,0 +1,145 @@
+package pager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// upstream serves the pages of a slice recording the requested page numbers
+type upstream struct {
+	items    []int
+	requests []int
+}
+
+func (u *upstream) FetchPage(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+	u.requests = append(u.requests, pageable.Page)
+	start := int(pageable.Offset())
+	if start > len(u.items) {
+		start = len(u.items)
+	}
+	end := start + pageable.Size
+	if end > len(u.items) {
+		end = len(u.items)
+	}
+	return data.NewPage(u.items[start:end], pageable, int64(len(u.items)))
+}
+
+func sequence(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func TestRechunkerStitchesUpstreamPages(t *testing.T) {
+	source := &upstream{items: sequence(250)}
+	rechunker := NewRechunker(source, 100, RechunkOptions{})
+
+	page, err := rechunker.FetchPage(context.Background(), data.NewPageable(3, 30))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(sequence(120)[90:], page.Content)
+	assert.Equal(3, page.Number)
+	assert.Equal(30, page.Size)
+	assert.Equal(int64(250), page.TotalElements)
+	assert.Equal(9, page.TotalPages)
+	assert.Equal([]int{0, 1}, source.requests)
+}
+
+func TestRechunkerWithOffsetPageable(t *testing.T) {
+	source := &upstream{items: sequence(250)}
+	rechunker := NewRechunker(source, 100, RechunkOptions{})
+
+	page, err := rechunker.FetchPage(context.Background(), data.NewOffsetPageable(195, 10, nil))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(sequence(205)[195:], page.Content)
+	assert.True(page.HasNext())
+	assert.Equal([]int{1, 2}, source.requests)
+}
+
+func TestRechunkerWithLastPage(t *testing.T) {
+	source := &upstream{items: sequence(250)}
+	rechunker := NewRechunker(source, 100, RechunkOptions{})
+
+	page, err := rechunker.FetchPage(context.Background(), data.NewPageable(1, 200))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(sequence(250)[200:], page.Content)
+	assert.True(page.IsLast())
+	assert.Equal([]int{2}, source.requests)
+}
+
+func TestRechunkerPastTheEnd(t *testing.T) {
+	source := &upstream{items: sequence(50)}
+	rechunker := NewRechunker(source, 100, RechunkOptions{})
+
+	page, err := rechunker.FetchPage(context.Background(), data.NewPageable(10, 10))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(0, page.NumberOfElements())
+	assert.Equal(int64(50), page.TotalElements)
+	assert.Equal([]int{1}, source.requests)
+}
+
+func TestRechunkerWithUnpagedRequest(t *testing.T) {
+	source := &upstream{items: sequence(250)}
+	rechunker := NewRechunker(source, 100, RechunkOptions{})
+
+	page, err := rechunker.FetchPage(context.Background(), data.Unpaged())
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(sequence(250), page.Content)
+	assert.Equal([]int{0, 1, 2}, source.requests)
+}
+
+func TestRechunkerReusesCachedPages(t *testing.T) {
+	source := &upstream{items: sequence(250)}
+	rechunker := NewRechunker(source, 100, RechunkOptions{CacheSize: 2})
+	ctx := context.Background()
+
+	rechunker.FetchPage(ctx, data.NewPageable(0, 30))
+	rechunker.FetchPage(ctx, data.NewPageable(3, 30))
+	rechunker.FetchPage(ctx, data.NewSortedPageable(0, 30, data.SortByProperties("name")))
+	rechunker.FetchPage(ctx, data.NewPageable(7, 30))
+	rechunker.FetchPage(ctx, data.NewPageable(0, 30))
+
+	assert.Equal(t, []int{0, 1, 0, 2, 0}, source.requests)
+
+	rechunker.Invalidate()
+	rechunker.FetchPage(ctx, data.NewPageable(4, 30))
+
+	assert.Equal(t, []int{0, 1, 0, 2, 0, 1}, source.requests)
+}
+
+func TestRechunkerWithExpiredPages(t *testing.T) {
+	source := &upstream{items: sequence(250)}
+	rechunker := NewRechunker(source, 100, RechunkOptions{CacheSize: 10, TTL: time.Millisecond})
+	ctx := context.Background()
+
+	rechunker.FetchPage(ctx, data.NewPageable(0, 30))
+	time.Sleep(5 * time.Millisecond)
+	rechunker.FetchPage(ctx, data.NewPageable(0, 30))
+
+	assert.Equal(t, []int{0, 0}, source.requests)
+}
+
+func TestRechunkerWithInvalidUpstreamPage(t *testing.T) {
+	rechunker := NewRechunker(data.PageFetcherFunc(func(ctx context.Context, pageable *data.Pageable) (*data.Page, error) {
+		return data.NewPage(sequence(20), data.NewPageable(0, 20), 20)
+	}), 10, RechunkOptions{})
+
+	_, err := rechunker.FetchPage(context.Background(), data.NewPageable(0, 5))
+
+	assert.Equal(t, ErrInvalidUpstreamPage, err)
+}
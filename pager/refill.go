@@ -0,0 +1,144 @@
+package pager
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// ErrInvalidCursor is returned when a continuation cursor cannot be decoded
+var ErrInvalidCursor = errors.New("Invalid cursor provided: it was not produced by this pager")
+
+// Predicate check if an item can be returned, e.g. if the current user is allowed to read it
+type Predicate[T any] func(ctx context.Context, item T) (bool, error)
+
+// RefillOptions contains the settings of a Refiller
+type RefillOptions struct {
+	// FetchSize is the size of the pages fetched from the source, the requested size when 0
+	FetchSize int
+	// MaxFetches is the maximum number of source pages fetched by a single request, no limit when 0. A request
+	// reaching it returns a short page with a cursor to continue from.
+	MaxFetches int
+}
+
+// RefillPage is a page of the items passing the predicate
+type RefillPage[T any] struct {
+	Content []T
+	Size    int
+	// Cursor continues right after the last examined item, empty when there are no more items
+	Cursor  string
+	HasNext bool
+	// TotalElements is the number of items passing the predicate when TotalIsExact, otherwise the number of items of
+	// the source, which is an upper bound
+	TotalElements int64
+	TotalIsExact  bool
+}
+
+// Refiller fills the pages of a source whose items are filtered after the fetch, fetching more items until the
+// requested size is reached
+type Refiller[T any] struct {
+	source    data.PageFetcher
+	predicate Predicate[T]
+	options   RefillOptions
+}
+
+// NewRefiller creates a new Refiller for the source, whose page contents must be []T, keeping the items accepted
+// by the predicate
+func NewRefiller[T any](source data.PageFetcher, predicate Predicate[T], options RefillOptions) *Refiller[T] {
+	return &Refiller[T]{source: source, predicate: predicate, options: options}
+}
+
+// Fetch returns up to size items passing the predicate, sorted by the provided Sort, starting from the cursor or
+// from the first item when the cursor is empty
+func (r *Refiller[T]) Fetch(ctx context.Context, cursor string, size int, sort *data.Sort) (*RefillPage[T], error) {
+	if size <= 0 {
+		return nil, data.ErrInvalidSize
+	}
+	offset, passed, err := decodeRefillCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	fetchSize := r.options.FetchSize
+	if fetchSize <= 0 {
+		fetchSize = size
+	}
+	content := make([]T, 0, size)
+	total, exhausted, lowerBound := int64(0), false, false
+	for fetches := 0; len(content) < size && (r.options.MaxFetches <= 0 || fetches < r.options.MaxFetches); fetches++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, err := r.source.FetchPage(ctx, data.NewOffsetPageable(offset, fetchSize, sort))
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			return nil, data.ErrInvalidContent
+		}
+		items, ok := page.Content.([]T)
+		if !ok {
+			return nil, data.ErrInvalidContent
+		}
+		total, lowerBound = page.TotalElements, page.TotalIsLowerBound
+		examined := 0
+		for _, item := range items {
+			if len(content) == size {
+				break
+			}
+			examined++
+			accepted, err := r.predicate(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			if accepted {
+				content = append(content, item)
+			}
+		}
+		offset += int64(examined)
+		if examined == len(items) && (len(items) == 0 || !page.HasNext()) {
+			exhausted = true
+			break
+		}
+	}
+	passed += int64(len(content))
+	result := &RefillPage[T]{Content: content, Size: size, HasNext: !exhausted, TotalElements: total}
+	if exhausted && !lowerBound {
+		result.TotalElements, result.TotalIsExact = passed, true
+	}
+	if result.HasNext {
+		result.Cursor = encodeRefillCursor(offset, passed)
+	}
+	return result, nil
+}
+
+// encodeRefillCursor encodes the offset of the next item to examine and the number of items accepted before it
+func encodeRefillCursor(offset, passed int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(offset, 10) + ":" + strconv.FormatInt(passed, 10)))
+}
+
+func decodeRefillCursor(cursor string) (int64, int64, error) {
+	if cursor == "" {
+		return 0, 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, ErrInvalidCursor
+	}
+	first, second, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return 0, 0, ErrInvalidCursor
+	}
+	offset, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, 0, ErrInvalidCursor
+	}
+	passed, err := strconv.ParseInt(second, 10, 64)
+	if err != nil || passed < 0 || passed > offset {
+		return 0, 0, ErrInvalidCursor
+	}
+	return offset, passed, nil
+}
@@ -0,0 +1,101 @@
+package pager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func even(ctx context.Context, item int) (bool, error) {
+	return item%2 == 0, nil
+}
+
+func TestRefillerFillsPages(t *testing.T) {
+	source := &upstream{items: sequence(25)}
+	refiller := NewRefiller(source, even, RefillOptions{})
+	ctx := context.Background()
+
+	assert := assert.New(t)
+	page, err := refiller.Fetch(ctx, "", 5, nil)
+	assert.Nil(err)
+	assert.Equal([]int{0, 2, 4, 6, 8}, page.Content)
+	assert.True(page.HasNext)
+	assert.False(page.TotalIsExact)
+	assert.Equal(int64(25), page.TotalElements)
+
+	page, err = refiller.Fetch(ctx, page.Cursor, 5, nil)
+	assert.Nil(err)
+	assert.Equal([]int{10, 12, 14, 16, 18}, page.Content)
+
+	page, err = refiller.Fetch(ctx, page.Cursor, 5, nil)
+	assert.Nil(err)
+	assert.Equal([]int{20, 22, 24}, page.Content)
+	assert.False(page.HasNext)
+	assert.Empty(page.Cursor)
+	assert.True(page.TotalIsExact)
+	assert.Equal(int64(13), page.TotalElements)
+}
+
+func TestRefillerResumesMidPage(t *testing.T) {
+	source := &upstream{items: sequence(30)}
+	refiller := NewRefiller(source, even, RefillOptions{FetchSize: 10})
+
+	page, _ := refiller.Fetch(context.Background(), "", 2, nil)
+	next, err := refiller.Fetch(context.Background(), page.Cursor, 2, nil)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]int{0, 2}, page.Content)
+	assert.Equal([]int{4, 6}, next.Content)
+	assert.Equal([]int{0, 0}, source.requests)
+}
+
+func TestRefillerWithMaxFetches(t *testing.T) {
+	source := &upstream{items: sequence(100)}
+	rare := func(ctx context.Context, item int) (bool, error) { return item%20 == 0, nil }
+	refiller := NewRefiller(source, rare, RefillOptions{FetchSize: 10, MaxFetches: 3})
+
+	page, err := refiller.Fetch(context.Background(), "", 5, nil)
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]int{0, 20}, page.Content)
+	assert.True(page.HasNext)
+
+	page, err = refiller.Fetch(context.Background(), page.Cursor, 5, nil)
+	assert.Nil(err)
+	assert.Equal([]int{40}, page.Content)
+	assert.True(page.HasNext)
+}
+
+func TestRefillerWithPredicateError(t *testing.T) {
+	failure := errors.New("failure")
+	refiller := NewRefiller(&upstream{items: sequence(10)}, func(ctx context.Context, item int) (bool, error) {
+		return false, failure
+	}, RefillOptions{})
+
+	_, err := refiller.Fetch(context.Background(), "", 5, nil)
+
+	assert.Equal(t, failure, err)
+}
+
+func TestRefillerWithInvalidRequests(t *testing.T) {
+	refiller := NewRefiller(&upstream{items: sequence(10)}, even, RefillOptions{})
+	ctx := context.Background()
+
+	assert := assert.New(t)
+	_, err := refiller.Fetch(ctx, "", 0, nil)
+	assert.Equal(data.ErrInvalidSize, err)
+	_, err = refiller.Fetch(ctx, "not a cursor", 5, nil)
+	assert.Equal(ErrInvalidCursor, err)
+	_, err = refiller.Fetch(ctx, encodeRefillCursor(1, 2), 5, nil)
+	assert.Equal(ErrInvalidCursor, err)
+
+	mismatched := NewRefiller(&upstream{items: sequence(10)}, func(ctx context.Context, item string) (bool, error) {
+		return true, nil
+	}, RefillOptions{})
+	_, err = mismatched.Fetch(ctx, "", 5, nil)
+	assert.Equal(data.ErrInvalidContent, err)
+}
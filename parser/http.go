@@ -40,9 +40,24 @@ type Params struct {
 	SortParam   string
 	DefaultPage int
 	DefaultSize int
+	// DefaultSort is the sort used when the request has no sort parameter, when nil the request is unsorted
+	DefaultSort *data.Sort
+	// TieBreaker is a unique property appended to every parsed sort, when not empty, to get a total ordering
+	TieBreaker string
 }
 
-var defaultParams = Params{DefaultPageParam, DefaultSizeParam, DefaultSortParam, DefaultPage, DefaultSize}
+var defaultParams = Params{
+	PageParam:   DefaultPageParam,
+	SizeParam:   DefaultSizeParam,
+	SortParam:   DefaultSortParam,
+	DefaultPage: DefaultPage,
+	DefaultSize: DefaultSize,
+}
+
+// DefaultParams returns a copy of the default parsing parameters
+func DefaultParams() Params {
+	return defaultParams
+}
 
 // ParseHTTPRequest will parse the provided HTTP request with default parameters
 func ParseHTTPRequest(req *http.Request) (*data.Pageable, error) {
@@ -84,7 +99,11 @@ func ParseValuesWithParams(values map[string][]string, params Params) (*data.Pag
 	if err != nil {
 		return nil, err
 	}
-	return data.NewSortedPageable(page, size, sort), nil
+	pageable := data.NewSortedPageable(page, size, sort)
+	if params.TieBreaker != "" {
+		return pageable.WithTieBreaker(params.TieBreaker), nil
+	}
+	return pageable, nil
 }
 
 func parsePage(values map[string][]string, params Params) (int, error) {
@@ -127,7 +146,7 @@ func parseSort(values map[string][]string, params Params) (*data.Sort, error) {
 		}
 		return sort, nil
 	}
-	return nil, nil
+	return data.Unsorted().And(params.DefaultSort), nil
 }
 
 func parseOrder(sort string) (*data.Sort, error) {
@@ -90,7 +90,7 @@ func TestParseValues(t *testing.T) {
 	assert.NotNil(pageable)
 	assert.Equal(5, pageable.Page)
 	assert.Equal(DefaultSize, pageable.Size)
-	assert.Nil(pageable.Sort)
+	assert.True(pageable.Sort.IsUnsorted())
 }
 
 func TestParseValuesWithMultiplePageValues(t *testing.T) {
@@ -135,7 +135,7 @@ func TestParseValuesWithSize(t *testing.T) {
 	assert.NotNil(pageable)
 	assert.Equal(DefaultPage, pageable.Page)
 	assert.Equal(25, pageable.Size)
-	assert.Nil(pageable.Sort)
+	assert.True(pageable.Sort.IsUnsorted())
 }
 
 func TestParseValuesWithMultipleSizeValues(t *testing.T) {
@@ -270,7 +270,7 @@ func TestPageableHttpParserValuesWithNoSort(t *testing.T) {
 
 	assert := assert.New(t)
 	assert.Nil(err)
-	assert.Nil(pageable.Sort)
+	assert.True(pageable.Sort.IsUnsorted())
 }
 
 func TestPageableHttpParserValuesWithSortNoValue(t *testing.T) {
@@ -281,3 +281,57 @@ func TestPageableHttpParserValuesWithSortNoValue(t *testing.T) {
 	assert.NotNil(err)
 	assert.Equal(ErrWrongSortValue, err)
 }
+
+func TestParseValuesWithTieBreaker(t *testing.T) {
+	values := map[string][]string{"sort": []string{"status,desc"}}
+	params := defaultParams
+	params.TieBreaker = "id"
+	pageable, err := ParseValuesWithParams(values, params)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(2, len(pageable.Sort.Orders))
+	assert.Equal("id", pageable.Sort.Orders[1].Property)
+	assert.Equal(data.Desc, pageable.Sort.Orders[1].Direction)
+}
+
+func TestParseValuesWithTieBreakerAndNoSort(t *testing.T) {
+	params := defaultParams
+	params.TieBreaker = "id"
+	pageable, err := ParseValuesWithParams(map[string][]string{}, params)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(1, len(pageable.Sort.Orders))
+	assert.Equal("id", pageable.Sort.Orders[0].Property)
+	assert.Equal(data.Asc, pageable.Sort.Orders[0].Direction)
+}
+
+func TestParseValuesWithDefaultSort(t *testing.T) {
+	params := defaultParams
+	params.DefaultSort = data.SortBy(data.Desc, "createdAt")
+	pageable, err := ParseValuesWithParams(map[string][]string{}, params)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(data.SortBy(data.Desc, "createdAt"), pageable.Sort)
+}
+
+func TestParseValuesWithDefaultSortOverridden(t *testing.T) {
+	values := map[string][]string{"sort": []string{"name"}}
+	params := defaultParams
+	params.DefaultSort = data.SortBy(data.Desc, "createdAt")
+	pageable, err := ParseValuesWithParams(values, params)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(data.SortByProperties("name"), pageable.Sort)
+}
+
+func TestDefaultParams(t *testing.T) {
+	params := DefaultParams()
+
+	assert := assert.New(t)
+	assert.Equal(DefaultPageParam, params.PageParam)
+	assert.Equal(DefaultSize, params.DefaultSize)
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// RangeHeader is the HTTP request header holding the requested items
+// RangeUnit is the unit of the item ranges
+const (
+	RangeHeader string = "Range"
+	RangeUnit   string = "items"
+)
+
+// ErrInvalidRange is returned when the Range header holds an invalid items range
+var ErrInvalidRange = errors.New("Wrong Range header value provided: expected items=<first>-<last>")
+
+// ParseRangeHTTPRequest will parse the items Range header of the provided HTTP request with default parameters,
+// falling back to the query parameters when it's missing
+func ParseRangeHTTPRequest(req *http.Request) (*data.Pageable, error) {
+	return ParseRangeHTTPRequestWithParams(req, defaultParams)
+}
+
+// ParseRangeHTTPRequestWithParams will parse the items Range header of the provided HTTP request given the provided
+// parameters into an offset based Pageable. The sort is always read from the query parameters, which are fully
+// used when the header is missing or uses another unit. Open ranges, like items=10-, request DefaultSize items.
+func ParseRangeHTTPRequestWithParams(req *http.Request, params Params) (*data.Pageable, error) {
+	header := req.Header.Get(RangeHeader)
+	if !strings.HasPrefix(header, RangeUnit+"=") {
+		return ParseHTTPRequestWithParams(req, params)
+	}
+	offset, size, err := parseRange(strings.TrimPrefix(header, RangeUnit+"="), params)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := parseSort(req.URL.Query(), params)
+	if err != nil {
+		return nil, err
+	}
+	pageable := data.NewOffsetPageable(offset, size, sort)
+	if params.TieBreaker != "" {
+		return pageable.WithTieBreaker(params.TieBreaker), nil
+	}
+	return pageable, nil
+}
+
+func parseRange(value string, params Params) (int64, int, error) {
+	bounds := strings.SplitN(strings.TrimSpace(value), "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, ErrInvalidRange
+	}
+	first, err := strconv.ParseInt(bounds[0], 10, 64)
+	if err != nil || first < 0 {
+		return 0, 0, ErrInvalidRange
+	}
+	if bounds[1] == "" {
+		return first, params.DefaultSize, nil
+	}
+	last, err := strconv.ParseInt(bounds[1], 10, 64)
+	if err != nil || last < first || last-first >= math.MaxInt32 {
+		return 0, 0, ErrInvalidRange
+	}
+	return first, int(last - first + 1), nil
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestParseRangeHTTPRequest(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/v1/api/list?sort=name,desc", nil)
+	req.Header.Set("Range", "items=25-49")
+	pageable, err := ParseRangeHTTPRequest(req)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(25), pageable.Offset())
+	assert.Equal(25, pageable.Size)
+	assert.Equal(data.SortBy(data.Desc, "name"), pageable.Sort)
+}
+
+func TestParseRangeHTTPRequestWithUnalignedRange(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/v1/api/list", nil)
+	req.Header.Set("Range", "items=3-7")
+	pageable, err := ParseRangeHTTPRequest(req)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(3), pageable.Offset())
+	assert.Equal(5, pageable.Size)
+}
+
+func TestParseRangeHTTPRequestWithOpenRange(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/v1/api/list", nil)
+	req.Header.Set("Range", "items=30-")
+	pageable, err := ParseRangeHTTPRequest(req)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(30), pageable.Offset())
+	assert.Equal(DefaultSize, pageable.Size)
+}
+
+func TestParseRangeHTTPRequestFallsBackToQuery(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/v1/api/list?page=2&size=5", nil)
+	req.Header.Set("Range", "bytes=0-99")
+	pageable, err := ParseRangeHTTPRequest(req)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(2, pageable.Page)
+	assert.Equal(5, pageable.Size)
+}
+
+func TestParseRangeHTTPRequestWithInvalidRange(t *testing.T) {
+	for _, value := range []string{"items=5", "items=9-3", "items=a-3", "items=0-4,10-14", "items=-5"} {
+		req, _ := http.NewRequest("GET", "http://localhost/v1/api/list", nil)
+		req.Header.Set("Range", value)
+		_, err := ParseRangeHTTPRequest(req)
+
+		assert.Equal(t, ErrInvalidRange, err, value)
+	}
+}
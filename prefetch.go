@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// PrefetchOptions contains the settings of a concurrent page walk
+type PrefetchOptions struct {
+	// Workers is the maximum number of concurrent fetches, 1 when lower
+	Workers int
+	// Ahead is the maximum number of pages fetched or in flight past the one being consumed, Workers when lower than 1
+	Ahead int
+}
+
+// prefetched is the outcome of a single fetch
+type prefetched struct {
+	page *Page
+	err  error
+}
+
+// PrefetchPages returns an iterator over the pages of the fetcher, like Pages, fetching them concurrently once
+// the first page reveals their total number. Pages are yielded in order and the first error occurred cancels the
+// fetches in flight. When the total is a lower bound the pages are fetched sequentially, and an unpaged request
+// yields a single page.
+func PrefetchPages(ctx context.Context, fetcher PageFetcher, start *Pageable, options PrefetchOptions) iter.Seq2[*Page, error] {
+	return func(yield func(*Page, error) bool) {
+		var first *Page
+		for page, err := range Pages(ctx, fetcher, start) {
+			if !yield(page, err) || err != nil || !page.HasContent() || !page.HasNext() || start.IsUnpaged() {
+				return
+			}
+			first = page
+			break
+		}
+		next := first.NextPageable()
+		if first.TotalIsLowerBound || next.Size <= 0 {
+			for page, err := range Pages(ctx, fetcher, next) {
+				if !yield(page, err) {
+					return
+				}
+			}
+			return
+		}
+		remaining := toInt((first.TotalElements-next.Offset()-1)/int64(next.Size) + 1)
+		workers := options.Workers
+		if workers < 1 {
+			workers = 1
+		}
+		ahead := options.Ahead
+		if ahead < 1 {
+			ahead = workers
+		}
+		ahead = min(ahead, remaining)
+
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel()
+
+		var once sync.Once
+		var firstErr error
+		fail := func(err error) {
+			once.Do(func() {
+				firstErr = err
+				cancel()
+			})
+		}
+		order := make(chan chan prefetched, ahead)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(order)
+			semaphore := make(chan struct{}, workers)
+			for pageable, i := next, 0; i < remaining; pageable, i = pageable.Next(), i+1 {
+				result := make(chan prefetched, 1)
+				select {
+				case order <- result:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case semaphore <- struct{}{}:
+				case <-ctx.Done():
+					result <- prefetched{nil, ctx.Err()}
+					return
+				}
+				wg.Add(1)
+				go func(pageable *Pageable) {
+					defer wg.Done()
+					defer func() { <-semaphore }()
+					page, err := fetcher.FetchPage(ctx, pageable)
+					if err == nil && page == nil {
+						err = ErrInvalidContent
+					}
+					if err != nil {
+						fail(err)
+					}
+					result <- prefetched{page, err}
+				}(pageable)
+			}
+		}()
+
+		for result := range order {
+			outcome := <-result
+			if outcome.err != nil {
+				fail(outcome.err)
+				yield(nil, firstErr)
+				return
+			}
+			if !yield(outcome.page, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrentFetcher serves the pages of a slice tracking the concurrent fetches
+type concurrentFetcher struct {
+	items    []int
+	delay    func(pageable *Pageable) time.Duration
+	fail     map[int]error
+	active   int32
+	peak     int32
+	mu       sync.Mutex
+	requests []int
+}
+
+func (f *concurrentFetcher) FetchPage(ctx context.Context, pageable *Pageable) (*Page, error) {
+	active := atomic.AddInt32(&f.active, 1)
+	defer atomic.AddInt32(&f.active, -1)
+	for {
+		peak := atomic.LoadInt32(&f.peak)
+		if active <= peak || atomic.CompareAndSwapInt32(&f.peak, peak, active) {
+			break
+		}
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, pageable.Page)
+	f.mu.Unlock()
+	if f.delay != nil {
+		select {
+		case <-time.After(f.delay(pageable)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err := f.fail[pageable.Page]; err != nil {
+		return nil, err
+	}
+	start := int(pageable.Offset())
+	end := start + pageable.Size
+	if end > len(f.items) {
+		end = len(f.items)
+	}
+	return NewPage(f.items[start:end], pageable, int64(len(f.items)))
+}
+
+func sequence(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func TestPrefetchPagesPreservesOrder(t *testing.T) {
+	fetcher := &concurrentFetcher{items: sequence(95), delay: func(pageable *Pageable) time.Duration {
+		return time.Duration(10-pageable.Page) * time.Millisecond
+	}}
+	var items []int
+	for page, err := range PrefetchPages(context.Background(), fetcher, NewPageable(0, 10), PrefetchOptions{Workers: 4}) {
+		assert.Nil(t, err)
+		items = append(items, page.Content.([]int)...)
+	}
+
+	assert.Equal(t, sequence(95), items)
+	assert.Equal(t, 10, len(fetcher.requests))
+	assert.True(t, fetcher.peak > 1)
+	assert.True(t, fetcher.peak <= 4)
+}
+
+func TestPrefetchPagesPropagatesFirstError(t *testing.T) {
+	failure := errors.New("failure")
+	fetcher := &concurrentFetcher{items: sequence(100), fail: map[int]error{5: failure}, delay: func(pageable *Pageable) time.Duration {
+		if pageable.Page == 5 {
+			return 0
+		}
+		return 50 * time.Millisecond
+	}}
+	var last error
+	pages := 0
+	for _, err := range PrefetchPages(context.Background(), fetcher, NewPageable(0, 10), PrefetchOptions{Workers: 8}) {
+		if err != nil {
+			last = err
+		} else {
+			pages++
+		}
+	}
+
+	assert.Equal(t, failure, last)
+	assert.Equal(t, 1, pages)
+}
+
+func TestPrefetchPagesWithAheadLimit(t *testing.T) {
+	fetcher := &concurrentFetcher{items: sequence(100)}
+	consumed := 0
+	for range PrefetchPages(context.Background(), fetcher, NewPageable(0, 10), PrefetchOptions{Workers: 4, Ahead: 2}) {
+		consumed++
+		time.Sleep(5 * time.Millisecond)
+		fetcher.mu.Lock()
+		requested := len(fetcher.requests)
+		fetcher.mu.Unlock()
+		assert.True(t, requested <= consumed+3, "requested %d pages after consuming %d", requested, consumed)
+	}
+
+	assert.Equal(t, 10, consumed)
+}
+
+func TestPrefetchPagesStopsWhenConsumerBreaks(t *testing.T) {
+	fetcher := &concurrentFetcher{items: sequence(1000), delay: func(pageable *Pageable) time.Duration {
+		return time.Millisecond
+	}}
+	consumed := 0
+	for range PrefetchPages(context.Background(), fetcher, NewPageable(0, 10), PrefetchOptions{Workers: 2, Ahead: 2}) {
+		consumed++
+		if consumed == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&fetcher.active))
+	assert.True(t, len(fetcher.requests) < 10)
+}
+
+func TestPrefetchPagesWithSinglePage(t *testing.T) {
+	fetcher := &concurrentFetcher{items: sequence(5)}
+	pages := 0
+	for range PrefetchPages(context.Background(), fetcher, NewPageable(0, 10), PrefetchOptions{Workers: 4}) {
+		pages++
+	}
+
+	assert.Equal(t, 1, pages)
+	assert.Equal(t, 1, len(fetcher.requests))
+}
+
+func TestPrefetchPagesOnUnpaged(t *testing.T) {
+	fetches := 0
+	fetcher := PageFetcherFunc(func(ctx context.Context, pageable *Pageable) (*Page, error) {
+		fetches++
+		return NewPage([]int{1, 2}, NewPageable(0, 2), 10)
+	})
+	pages := 0
+	for _, err := range PrefetchPages(context.Background(), fetcher, nil, PrefetchOptions{Workers: 4}) {
+		assert.Nil(t, err)
+		pages++
+	}
+
+	assert.Equal(t, 1, pages)
+	assert.Equal(t, 1, fetches)
+}
+
+func TestPrefetchPagesWithLargeTotal(t *testing.T) {
+	fetcher := PageFetcherFunc(func(ctx context.Context, pageable *Pageable) (*Page, error) {
+		return NewPage([]int{1}, pageable, 1_000_000_000)
+	})
+	pages := 0
+	for _, err := range PrefetchPages(context.Background(), fetcher, NewPageable(0, 1), PrefetchOptions{Workers: 2}) {
+		assert.Nil(t, err)
+		if pages++; pages == 5 {
+			break
+		}
+	}
+
+	assert.Equal(t, 5, pages)
+}
+
+func TestPrefetchPagesWithLowerBoundTotal(t *testing.T) {
+	fetcher := PageFetcherFunc(func(ctx context.Context, pageable *Pageable) (*Page, error) {
+		if pageable.Page == 3 {
+			return NewLowerBoundPage([]int{}, pageable, 3)
+		}
+		return NewLowerBoundPage([]int{1}, pageable, 2)
+	})
+	pages := 0
+	for _, err := range PrefetchPages(context.Background(), fetcher, NewPageable(0, 1), PrefetchOptions{Workers: 4}) {
+		assert.Nil(t, err)
+		pages++
+	}
+
+	assert.Equal(t, 4, pages)
+}
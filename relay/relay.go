@@ -0,0 +1,192 @@
+package relay
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// ErrInvalidCursor is returned when a cursor was not produced by this package
+// ErrInvalidFirst is returned when the first argument is lower than 1
+// ErrInvalidLast is returned when the last argument is lower than 1
+// ErrMissingLimit is returned when neither the first nor the last argument are provided
+// ErrEmptyRange is returned when the after and before cursors select no element, to be answered with an empty connection
+// ErrInvalidContent is returned when the page content is not a slice of the connection node type
+var (
+	ErrInvalidCursor  = errors.New("Invalid cursor provided")
+	ErrInvalidFirst   = errors.New("Invalid first value provided: expected a value greater or equal than 1")
+	ErrInvalidLast    = errors.New("Invalid last value provided: expected a value greater or equal than 1")
+	ErrMissingLimit   = errors.New("Missing first or last value: one of them is required")
+	ErrEmptyRange     = errors.New("Empty range: the after and before cursors select no element")
+	ErrInvalidContent = errors.New("Invalid page content: expected a slice of the connection node type")
+)
+
+const cursorPrefix = "offset:"
+
+// OrderBy is the orderBy input of a connection field
+type OrderBy struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction"`
+}
+
+// Args are the arguments of a connection field
+type Args struct {
+	First   *int      `json:"first"`
+	After   *string   `json:"after"`
+	Last    *int      `json:"last"`
+	Before  *string   `json:"before"`
+	OrderBy []OrderBy `json:"orderBy"`
+}
+
+// Connection is the result of a connection field
+type Connection[T any] struct {
+	Edges      []Edge[T] `json:"edges"`
+	PageInfo   PageInfo  `json:"pageInfo"`
+	TotalCount *int64    `json:"totalCount,omitempty"`
+}
+
+// Edge holds a connection node along with its cursor
+type Edge[T any] struct {
+	Cursor string `json:"cursor"`
+	Node   T      `json:"node"`
+}
+
+// PageInfo holds the pagination details of a connection
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+// EncodeCursor returns the opaque cursor of the element at provided offset
+func EncodeCursor(offset int64) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatInt(offset, 10)))
+}
+
+// DecodeCursor returns the offset of the element identified by the provided cursor. The greatest int64 is not a
+// valid offset, as no element can follow it.
+func DecodeCursor(cursor string) (int64, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return 0, ErrInvalidCursor
+	}
+	offset, err := strconv.ParseInt(strings.TrimPrefix(string(decoded), cursorPrefix), 10, 64)
+	if err != nil || offset < 0 || offset == math.MaxInt64 {
+		return 0, ErrInvalidCursor
+	}
+	return offset, nil
+}
+
+// Sort converts the orderBy input into a Sort object. Directions are case insensitive.
+func Sort(orderBy []OrderBy) (*data.Sort, error) {
+	sort := data.Unsorted()
+	for _, o := range orderBy {
+		direction := data.Asc
+		if o.Direction != "" {
+			parsed, err := data.ParseDirection(o.Direction)
+			if err != nil {
+				return nil, err
+			}
+			direction = parsed
+		}
+		sort = sort.And(data.SortBy(direction, o.Field))
+	}
+	return sort, nil
+}
+
+// Pageable converts the connection arguments into an offset based Pageable. The count function is only called
+// to locate the end of the results when last is provided without before, and it may be nil otherwise. Windows ending
+// past the greatest int64 return data.ErrOffsetOverflow.
+func (args Args) Pageable(count func() (int64, error)) (*data.Pageable, error) {
+	if args.First == nil && args.Last == nil {
+		return nil, ErrMissingLimit
+	}
+	sort, err := Sort(args.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	start, end := int64(0), int64(-1)
+	if args.After != nil {
+		after, err := DecodeCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		start = after + 1
+	}
+	if args.Before != nil {
+		before, err := DecodeCursor(*args.Before)
+		if err != nil {
+			return nil, err
+		}
+		end = before
+	}
+	if args.First != nil {
+		if *args.First < 1 {
+			return nil, ErrInvalidFirst
+		}
+		if start > math.MaxInt64-int64(*args.First) {
+			return nil, data.ErrOffsetOverflow
+		}
+		if end < 0 || start+int64(*args.First) < end {
+			end = start + int64(*args.First)
+		}
+	}
+	if args.Last != nil {
+		if *args.Last < 1 {
+			return nil, ErrInvalidLast
+		}
+		if end < 0 {
+			if end, err = count(); err != nil {
+				return nil, err
+			}
+		}
+		if end-int64(*args.Last) > start {
+			start = end - int64(*args.Last)
+		}
+	}
+	if end <= start {
+		if args.Before != nil {
+			return nil, ErrEmptyRange
+		}
+		// the window starts past the end of the results, so it's empty whatever its size
+		return data.NewOffsetPageable(start, 1, sort), nil
+	}
+	return data.NewOffsetPageable(start, int(end-start), sort), nil
+}
+
+// FromPage converts the provided page, whose content must be a []T, into a connection
+func FromPage[T any](page *data.Page) (*Connection[T], error) {
+	nodes, ok := page.Content.([]T)
+	if !ok {
+		return nil, ErrInvalidContent
+	}
+	offset := page.Pageable.Offset()
+	total := page.TotalElements
+	connection := FromNodes(nodes, func(i int, node T) string {
+		return EncodeCursor(offset + int64(i))
+	}, page.HasPrevious(), page.HasNext())
+	connection.TotalCount = &total
+	return connection, nil
+}
+
+// FromNodes creates a connection from the provided nodes of a cursor based result, given the function producing
+// the cursor of every node
+func FromNodes[T any](nodes []T, cursor func(i int, node T) string, hasPrevious, hasNext bool) *Connection[T] {
+	connection := &Connection[T]{
+		Edges:    make([]Edge[T], len(nodes)),
+		PageInfo: PageInfo{HasNextPage: hasNext, HasPreviousPage: hasPrevious},
+	}
+	for i, node := range nodes {
+		connection.Edges[i] = Edge[T]{Cursor: cursor(i, node), Node: node}
+	}
+	if len(nodes) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(nodes)-1].Cursor
+	}
+	return connection
+}
@@ -0,0 +1,148 @@
+package relay
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func cursorPtr(offset int64) *string {
+	cursor := EncodeCursor(offset)
+	return &cursor
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	offset, err := DecodeCursor(EncodeCursor(42))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(42), offset)
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	_, err := DecodeCursor("bm9wZTox")
+
+	assert.Equal(t, ErrInvalidCursor, err)
+}
+
+func TestDecodeCursorAtMaxOffset(t *testing.T) {
+	_, err := Args{First: intPtr(10), After: cursorPtr(math.MaxInt64)}.Pageable(nil)
+
+	assert.Equal(t, ErrInvalidCursor, err)
+}
+
+func TestPageableWithOverflowingFirst(t *testing.T) {
+	_, err := Args{First: intPtr(10), After: cursorPtr(math.MaxInt64 - 5)}.Pageable(nil)
+
+	assert.Equal(t, data.ErrOffsetOverflow, err)
+}
+
+func TestPageableWithFirst(t *testing.T) {
+	pageable, err := Args{First: intPtr(10)}.Pageable(nil)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(0), pageable.Offset())
+	assert.Equal(10, pageable.Size)
+}
+
+func TestPageableWithFirstAfter(t *testing.T) {
+	args := Args{First: intPtr(10), After: cursorPtr(14), OrderBy: []OrderBy{{"name", "DESC"}, {Field: "id"}}}
+	pageable, err := args.Pageable(nil)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(15), pageable.Offset())
+	assert.Equal(10, pageable.Size)
+	assert.Equal(data.NewSort(data.OrderBy("name", data.Desc), data.OrderByProperty("id")), pageable.Sort)
+}
+
+func TestPageableWithLastBefore(t *testing.T) {
+	pageable, err := Args{Last: intPtr(5), Before: cursorPtr(20)}.Pageable(nil)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(15), pageable.Offset())
+	assert.Equal(5, pageable.Size)
+}
+
+func TestPageableWithLastCountsElements(t *testing.T) {
+	pageable, err := Args{Last: intPtr(5)}.Pageable(func() (int64, error) { return 42, nil })
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(37), pageable.Offset())
+	assert.Equal(5, pageable.Size)
+}
+
+func TestPageableWithLastPropagatesCountError(t *testing.T) {
+	failure := errors.New("failure")
+	_, err := Args{Last: intPtr(5)}.Pageable(func() (int64, error) { return 0, failure })
+
+	assert.Equal(t, failure, err)
+}
+
+func TestPageableWithFirstAndLast(t *testing.T) {
+	pageable, err := Args{First: intPtr(10), Last: intPtr(3), After: cursorPtr(9)}.Pageable(nil)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(17), pageable.Offset())
+	assert.Equal(3, pageable.Size)
+}
+
+func TestPageableWithInvalidArgs(t *testing.T) {
+	_, missing := Args{}.Pageable(nil)
+	_, first := Args{First: intPtr(0)}.Pageable(nil)
+	_, last := Args{Last: intPtr(-1), Before: cursorPtr(3)}.Pageable(nil)
+	_, empty := Args{First: intPtr(3), After: cursorPtr(5), Before: cursorPtr(6)}.Pageable(nil)
+	_, direction := Args{First: intPtr(3), OrderBy: []OrderBy{{"name", "up"}}}.Pageable(nil)
+
+	assert := assert.New(t)
+	assert.Equal(ErrMissingLimit, missing)
+	assert.Equal(ErrInvalidFirst, first)
+	assert.Equal(ErrInvalidLast, last)
+	assert.Equal(ErrEmptyRange, empty)
+	assert.Equal(data.ErrInvalidDirection, direction)
+}
+
+func TestFromPage(t *testing.T) {
+	page, _ := data.NewPage([]string{"p", "q"}, data.NewOffsetPageable(15, 2, nil), 20)
+	connection, err := FromPage[string](page)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(2, len(connection.Edges))
+	assert.Equal("p", connection.Edges[0].Node)
+	assert.Equal(EncodeCursor(15), connection.Edges[0].Cursor)
+	assert.Equal(EncodeCursor(16), connection.Edges[1].Cursor)
+	assert.Equal(EncodeCursor(15), *connection.PageInfo.StartCursor)
+	assert.Equal(EncodeCursor(16), *connection.PageInfo.EndCursor)
+	assert.True(connection.PageInfo.HasNextPage)
+	assert.True(connection.PageInfo.HasPreviousPage)
+	assert.Equal(int64(20), *connection.TotalCount)
+}
+
+func TestFromPageWithWrongContent(t *testing.T) {
+	page, _ := data.NewPage([]int{1}, data.NewPageable(0, 2), 1)
+	_, err := FromPage[string](page)
+
+	assert.Equal(t, ErrInvalidContent, err)
+}
+
+func TestFromNodesJSON(t *testing.T) {
+	connection := FromNodes([]string{}, func(i int, node string) string { return node }, false, false)
+	encoded, err := json.Marshal(connection)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.JSONEq(`{"edges":[],"pageInfo":{"hasNextPage":false,"hasPreviousPage":false,"startCursor":null,"endCursor":null}}`, string(encoded))
+}
@@ -0,0 +1,108 @@
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// ETagHeader is the HTTP response header holding the entity tag
+// IfNoneMatchHeader is the HTTP request header holding the entity tags known by the client
+const (
+	ETagHeader        string = "ETag"
+	IfNoneMatchHeader string = "If-None-Match"
+)
+
+// ETag returns a strong entity tag for the provided page, hashing its content along with the pagination metadata
+// and sort
+func ETag(page *data.Page) (string, error) {
+	encoded, err := json.Marshal(page)
+	if err != nil {
+		return "", err
+	}
+	return `"` + hash(encoded, metadata(page)) + `"`, nil
+}
+
+// WeakETag returns a weak entity tag for the provided page derived from the given collection version, which must
+// change whenever the collection does, along with the pagination metadata and sort. The content is not hashed.
+func WeakETag(page *data.Page, version string) (string, error) {
+	encoded, err := json.Marshal(page.Sort)
+	if err != nil {
+		return "", err
+	}
+	return `W/"` + hash([]byte(version), encoded, metadata(page)) + `"`, nil
+}
+
+// NotModified sets the ETag header and check if the request If-None-Match header matches the provided entity tag.
+// When matching it writes a 304 Not Modified response and returns true.
+func NotModified(w http.ResponseWriter, req *http.Request, etag string) bool {
+	w.Header().Set(ETagHeader, etag)
+	if !matches(req.Header.Values(IfNoneMatchHeader), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// WritePage writes the provided page as JSON with a strong entity tag, answering 304 Not Modified when the
+// request already holds it
+func WritePage(w http.ResponseWriter, req *http.Request, page *data.Page) error {
+	etag, err := ETag(page)
+	if err != nil {
+		return err
+	}
+	return writeTagged(w, req, page, etag)
+}
+
+// WritePageWithVersion writes the provided page as JSON with a weak entity tag derived from the collection
+// version, answering 304 Not Modified when the request already holds it
+func WritePageWithVersion(w http.ResponseWriter, req *http.Request, page *data.Page, version string) error {
+	etag, err := WeakETag(page, version)
+	if err != nil {
+		return err
+	}
+	return writeTagged(w, req, page, etag)
+}
+
+func writeTagged(w http.ResponseWriter, req *http.Request, page *data.Page, etag string) error {
+	if NotModified(w, req, etag) {
+		return nil
+	}
+	return JSON(w, http.StatusOK, page)
+}
+
+// metadata returns the pagination values identifying a page request
+func metadata(page *data.Page) []byte {
+	var offset int64
+	if page.Pageable != nil {
+		offset = page.Pageable.Offset()
+	}
+	return []byte(strconv.FormatInt(offset, 10) + "/" + strconv.Itoa(page.Size) + "/" + page.FormatTotal())
+}
+
+func hash(parts ...[]byte) string {
+	digest := sha256.New()
+	for _, part := range parts {
+		digest.Write(part)
+		digest.Write([]byte{0})
+	}
+	return hex.EncodeToString(digest.Sum(nil)[:16])
+}
+
+// matches check if any of the If-None-Match values matches the entity tag with the weak comparison
+func matches(values []string, etag string) bool {
+	for _, value := range values {
+		for _, candidate := range strings.Split(value, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package render
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestETagChangesWithContentAndMetadata(t *testing.T) {
+	page, _ := data.NewPage([]int{1, 2}, data.NewPageable(0, 2), 5)
+	same, _ := data.NewPage([]int{1, 2}, data.NewPageable(0, 2), 5)
+	content, _ := data.NewPage([]int{1, 3}, data.NewPageable(0, 2), 5)
+	sorted, _ := data.NewPage([]int{1, 2}, data.NewSortedPageable(0, 2, data.SortByProperties("id")), 5)
+	total, _ := data.NewPage([]int{1, 2}, data.NewPageable(0, 2), 6)
+
+	assert := assert.New(t)
+	etag, err := ETag(page)
+	assert.Nil(err)
+	assert.Regexp(`^"[0-9a-f]{32}"$`, etag)
+	for _, other := range []*data.Page{content, sorted, total} {
+		otherTag, _ := ETag(other)
+		assert.NotEqual(etag, otherTag)
+	}
+	sameTag, _ := ETag(same)
+	assert.Equal(etag, sameTag)
+}
+
+func TestWeakETagDependsOnVersion(t *testing.T) {
+	page, _ := data.NewPage([]int{1, 2}, data.NewPageable(0, 2), 5)
+	next, _ := data.NewPage([]int{3, 4}, data.NewPageable(1, 2), 5)
+	first, _ := WeakETag(page, "v1")
+	second, _ := WeakETag(page, "v2")
+	other, _ := WeakETag(next, "v1")
+
+	assert := assert.New(t)
+	assert.Regexp(`^W/"[0-9a-f]{32}"$`, first)
+	assert.NotEqual(first, second)
+	assert.NotEqual(first, other)
+}
+
+func TestWritePageAnswersNotModified(t *testing.T) {
+	page, _ := data.NewPage([]int{1, 2}, data.NewPageable(0, 2), 5)
+	etag, _ := ETag(page)
+	req := httptest.NewRequest("GET", "/items", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	recorder := httptest.NewRecorder()
+	err := WritePage(recorder, req, page)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(http.StatusNotModified, recorder.Code)
+	assert.Equal(etag, recorder.Header().Get("ETag"))
+	assert.Equal("", recorder.Body.String())
+}
+
+func TestWritePageWithStaleETag(t *testing.T) {
+	page, _ := data.NewPage([]int{1, 2}, data.NewPageable(0, 2), 5)
+	req := httptest.NewRequest("GET", "/items", nil)
+	req.Header.Set("If-None-Match", `"stale"`)
+	recorder := httptest.NewRecorder()
+	err := WritePage(recorder, req, page)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.NotEmpty(recorder.Header().Get("ETag"))
+	assert.JSONEq(`{"content":[1,2],"number":0,"size":2,"totalPages":3,"totalElements":5,"sort":{"orders":[]}}`, recorder.Body.String())
+}
+
+func TestWritePageWithVersionMatchesWeakly(t *testing.T) {
+	page, _ := data.NewPage([]int{1, 2}, data.NewPageable(0, 2), 5)
+	etag, _ := WeakETag(page, "42")
+	req := httptest.NewRequest("GET", "/items", nil)
+	req.Header.Set("If-None-Match", etag[2:])
+	recorder := httptest.NewRecorder()
+	WritePageWithVersion(recorder, req, page, "42")
+
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+}
@@ -0,0 +1,71 @@
+package render
+
+import (
+	"net/http"
+	"strconv"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// AcceptRangesHeader is the HTTP response header advertising the items ranges
+// ContentRangeHeader is the HTTP response header describing the returned items
+const (
+	AcceptRangesHeader string = "Accept-Ranges"
+	ContentRangeHeader string = "Content-Range"
+)
+
+// ContentRange returns the Content-Range header value for the provided page, like items 0-24/319. The total is
+// replaced by an asterisk when it's a lower bound, and the range as well when the page is not satisfiable.
+func ContentRange(page *data.Page) string {
+	total := strconv.FormatInt(page.TotalElements, 10)
+	if page.TotalIsLowerBound {
+		total = "*"
+	}
+	if !IsRangeSatisfiable(page) || !page.HasContent() {
+		return "items */" + total
+	}
+	first := page.Pageable.Offset()
+	last := first + int64(page.NumberOfElements()) - 1
+	return "items " + strconv.FormatInt(first, 10) + "-" + strconv.FormatInt(last, 10) + "/" + total
+}
+
+// IsRangeSatisfiable check if the page starts within the elements, or it's the first page of an empty result
+func IsRangeSatisfiable(page *data.Page) bool {
+	offset := page.Pageable.Offset()
+	return offset == 0 || offset < page.TotalElements || (page.TotalIsLowerBound && page.HasContent())
+}
+
+// RangeStatus returns the status code of a ranged response for the provided page: 206 Partial Content when it
+// holds a part of the elements, 200 OK when it holds all of them, and 416 Range Not Satisfiable when it starts
+// past their end
+func RangeStatus(page *data.Page) int {
+	switch {
+	case !IsRangeSatisfiable(page):
+		return http.StatusRequestedRangeNotSatisfiable
+	case page.TotalIsLowerBound || page.HasPrevious() || page.HasNext():
+		return http.StatusPartialContent
+	default:
+		return http.StatusOK
+	}
+}
+
+// WriteRange writes the range headers and status code for the provided page, followed by its content as JSON with
+// a strong entity tag, answering 304 Not Modified when the request already holds it. Not satisfiable ranges get no
+// body.
+func WriteRange(w http.ResponseWriter, req *http.Request, page *data.Page) error {
+	status := RangeStatus(page)
+	w.Header().Set(AcceptRangesHeader, "items")
+	w.Header().Set(ContentRangeHeader, ContentRange(page))
+	if status == http.StatusRequestedRangeNotSatisfiable {
+		w.WriteHeader(status)
+		return nil
+	}
+	etag, err := ETag(page)
+	if err != nil {
+		return err
+	}
+	if NotModified(w, req, etag) {
+		return nil
+	}
+	return JSON(w, status, page.Content)
+}
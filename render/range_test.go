@@ -0,0 +1,81 @@
+package render
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestWriteRangeWithPartialContent(t *testing.T) {
+	page, _ := data.NewPage([]int{1, 2, 3}, data.NewOffsetPageable(0, 3, nil), 319)
+	recorder := httptest.NewRecorder()
+	err := WriteRange(recorder, httptest.NewRequest(http.MethodGet, "/items", nil), page)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(http.StatusPartialContent, recorder.Code)
+	assert.Equal("items", recorder.Header().Get("Accept-Ranges"))
+	assert.Equal("items 0-2/319", recorder.Header().Get("Content-Range"))
+	assert.Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.JSONEq("[1,2,3]", recorder.Body.String())
+}
+
+func TestWriteRangeWithWholeContent(t *testing.T) {
+	page, _ := data.NewPage([]int{1, 2}, data.NewOffsetPageable(0, 25, nil), 2)
+	recorder := httptest.NewRecorder()
+	WriteRange(recorder, httptest.NewRequest(http.MethodGet, "/items", nil), page)
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.Equal("items 0-1/2", recorder.Header().Get("Content-Range"))
+}
+
+func TestWriteRangeNotSatisfiable(t *testing.T) {
+	page, _ := data.NewPage([]int{}, data.NewOffsetPageable(400, 25, nil), 319)
+	recorder := httptest.NewRecorder()
+	WriteRange(recorder, httptest.NewRequest(http.MethodGet, "/items", nil), page)
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusRequestedRangeNotSatisfiable, recorder.Code)
+	assert.Equal("items */319", recorder.Header().Get("Content-Range"))
+	assert.Equal("", recorder.Body.String())
+}
+
+func TestWriteRangeOnEmptyResult(t *testing.T) {
+	page, _ := data.NewPage([]int{}, data.NewOffsetPageable(0, 25, nil), 0)
+	recorder := httptest.NewRecorder()
+	WriteRange(recorder, httptest.NewRequest(http.MethodGet, "/items", nil), page)
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.Equal("items */0", recorder.Header().Get("Content-Range"))
+}
+
+func TestWriteRangeNotModified(t *testing.T) {
+	page, _ := data.NewPage([]int{1, 2, 3}, data.NewOffsetPageable(0, 3, nil), 319)
+	recorder := httptest.NewRecorder()
+	WriteRange(recorder, httptest.NewRequest(http.MethodGet, "/items", nil), page)
+	etag := recorder.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	err := WriteRange(recorder, req, page)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.NotEmpty(etag)
+	assert.Equal(http.StatusNotModified, recorder.Code)
+	assert.Equal(etag, recorder.Header().Get("ETag"))
+	assert.Equal("", recorder.Body.String())
+}
+
+func TestContentRangeWithLowerBoundTotal(t *testing.T) {
+	page, _ := data.NewLowerBoundPage([]int{1, 2}, data.NewOffsetPageable(10, 2, nil), 12)
+
+	assert.Equal(t, "items 10-11/*", ContentRange(page))
+	assert.Equal(t, http.StatusPartialContent, RangeStatus(page))
+}
@@ -0,0 +1,14 @@
+// Package render writes pages into HTTP responses
+package render
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// JSON writes the provided value as a JSON response body with given status code
+func JSON(w http.ResponseWriter, status int, value interface{}) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,105 @@
+package data
+
+import "reflect"
+
+// Slice is the struct used to hold a single page of data whose total number of elements is unknown. It only
+// knows if a next slice exists, which is cheaper than counting the elements.
+type Slice struct {
+	Content interface{} `json:"content"`
+	Number  int         `json:"number"`
+	Size    int         `json:"size"`
+	HasMore bool        `json:"hasNext"`
+	Sort    *Sort       `json:"sort"`
+	// Pageable is the request originating the slice
+	Pageable *Pageable `json:"-"`
+}
+
+// NewSlice create a new Slice object with provided content, pagination object and next slice flag
+func NewSlice(content interface{}, pageable *Pageable, hasNext bool) (*Slice, error) {
+	page, err := NewPage(content, pageable, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Slice{
+		Content:  page.Content,
+		Number:   page.Number,
+		Size:     page.Size,
+		HasMore:  hasNext && pageable.IsPaged(),
+		Sort:     page.Sort,
+		Pageable: page.Pageable,
+	}, nil
+}
+
+// NewSliceFromLookahead create a new Slice object from a content slice fetched with one element more than the
+// requested size: the extra element reveals the next slice and it's dropped from the content.
+func NewSliceFromLookahead(content interface{}, pageable *Pageable) (*Slice, error) {
+	if err := validateContent(content); err != nil {
+		return nil, err
+	}
+	value := reflect.ValueOf(content)
+	if pageable.IsPaged() && value.Len() > pageable.Size {
+		if value.Kind() != reflect.Slice {
+			return nil, ErrInvalidContent
+		}
+		return NewSlice(value.Slice(0, pageable.Size).Interface(), pageable, true)
+	}
+	return NewSlice(content, pageable, false)
+}
+
+// HasPrevious check if the slice has a slice before this one
+func (slice *Slice) HasPrevious() bool {
+	if slice.Pageable.IsOffsetBased() {
+		return slice.Pageable.HasPrevious()
+	}
+	return slice.Number > 0
+}
+
+// HasNext check if the slice has a slice over this one
+func (slice *Slice) HasNext() bool {
+	return slice.HasMore
+}
+
+// IsFirst check if the slice is the first one
+func (slice *Slice) IsFirst() bool {
+	return !slice.HasPrevious()
+}
+
+// IsLast check if the slice is the last one
+func (slice *Slice) IsLast() bool {
+	return !slice.HasMore
+}
+
+// NumberOfElements returns the number of elements held by the slice
+func (slice *Slice) NumberOfElements() int {
+	return reflect.ValueOf(slice.Content).Len()
+}
+
+// HasContent check if the slice has content
+func (slice *Slice) HasContent() bool {
+	return slice.NumberOfElements() > 0
+}
+
+// NextPageable returns the request for the next slice, or nil if the slice is the last one
+func (slice *Slice) NextPageable() *Pageable {
+	if !slice.HasNext() {
+		return nil
+	}
+	return slice.request().Next()
+}
+
+// PreviousPageable returns the request for the previous slice, or nil if the slice is the first one
+func (slice *Slice) PreviousPageable() *Pageable {
+	if !slice.HasPrevious() {
+		return nil
+	}
+	return slice.request().Previous()
+}
+
+// request returns the request originating the slice, or the one matching its number, size and sort when the slice
+// was decoded or built without it
+func (slice *Slice) request() *Pageable {
+	if slice.Pageable != nil {
+		return slice.Pageable
+	}
+	return NewSortedPageable(slice.Number, slice.Size, slice.Sort)
+}
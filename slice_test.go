@@ -0,0 +1,49 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlice(t *testing.T) {
+	slice, err := NewSlice([]int{1, 2, 3}, NewSortedPageable(1, 3, SortByProperties("name")), true)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]int{1, 2, 3}, slice.Content)
+	assert.Equal(1, slice.Number)
+	assert.Equal(3, slice.Size)
+	assert.Equal(SortByProperties("name"), slice.Sort)
+	assert.True(slice.HasNext())
+	assert.True(slice.HasPrevious())
+	assert.False(slice.IsFirst())
+	assert.False(slice.IsLast())
+	assert.Equal(NewSortedPageable(2, 3, SortByProperties("name")), slice.NextPageable())
+	assert.Equal(NewSortedPageable(0, 3, SortByProperties("name")), slice.PreviousPageable())
+}
+
+func TestNewSliceWithInvalidContent(t *testing.T) {
+	_, err := NewSlice("wrong", NewPageable(0, 3), false)
+
+	assert.Equal(t, ErrInvalidContent, err)
+}
+
+func TestNewSliceFromLookaheadWithExtraElement(t *testing.T) {
+	slice, err := NewSliceFromLookahead([]int{1, 2, 3, 4}, NewPageable(0, 3))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]int{1, 2, 3}, slice.Content)
+	assert.True(slice.HasNext())
+}
+
+func TestNewSliceFromLookaheadOnLastSlice(t *testing.T) {
+	slice, err := NewSliceFromLookahead([]int{1, 2}, NewPageable(2, 3))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(2, slice.NumberOfElements())
+	assert.True(slice.IsLast())
+	assert.Nil(slice.NextPageable())
+}
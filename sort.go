@@ -14,7 +14,7 @@ var (
 
 // Sort options for queries
 type Sort struct {
-	Orders []Order
+	Orders []Order `json:"orders"`
 }
 
 // EmptySort will create a new empty sort object
@@ -22,6 +22,11 @@ func EmptySort() *Sort {
 	return &Sort{Orders: make([]Order, 0)}
 }
 
+// Unsorted will create a new Sort object not requesting any ordering
+func Unsorted() *Sort {
+	return EmptySort()
+}
+
 // NewSort create a new Sort object with provided order clauses
 func NewSort(order Order, orders ...Order) *Sort {
 	target := make([]Order, 1, len(orders)+1)
@@ -47,13 +52,118 @@ func SortByProperties(property string, properties ...string) *Sort {
 
 // And will join the provided Sort object with the target one and produces a new Sort instance
 func (sort *Sort) And(other *Sort) *Sort {
-	target := append(sort.Orders, other.Orders...)
+	target := make([]Order, 0, len(sort.orders())+len(other.orders()))
+	target = append(target, sort.orders()...)
+	return &Sort{Orders: append(target, other.orders()...)}
+}
+
+// Reverse returns a new Sort instance with the direction of every order inverted
+func (sort *Sort) Reverse() *Sort {
+	target := make([]Order, len(sort.orders()))
+	for i, order := range sort.orders() {
+		target[i] = order.Reverse()
+	}
 	return &Sort{Orders: target}
 }
 
-// IsEmpty will check if the sort contains properties
+// OrderFor returns the order for the provided property, reporting whether it was found
+func (sort *Sort) OrderFor(property string) (Order, bool) {
+	for _, order := range sort.orders() {
+		if order.Property == property {
+			return order, true
+		}
+	}
+	return Order{}, false
+}
+
+// Without returns a new Sort instance without the orders for provided properties
+func (sort *Sort) Without(properties ...string) *Sort {
+	return sort.Filter(func(order Order) bool {
+		for _, property := range properties {
+			if order.Property == property {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Filter returns a new Sort instance with the orders matching the provided predicate
+func (sort *Sort) Filter(predicate func(Order) bool) *Sort {
+	target := make([]Order, 0, len(sort.orders()))
+	for _, order := range sort.orders() {
+		if predicate(order) {
+			target = append(target, order)
+		}
+	}
+	return &Sort{Orders: target}
+}
+
+// Dedupe returns a new Sort instance keeping only the first order for every property. The dropped orders having a
+// direction different from the kept one are returned as conflicts.
+func (sort *Sort) Dedupe() (*Sort, []Order) {
+	target := make([]Order, 0, len(sort.orders()))
+	var conflicts []Order
+	for _, order := range sort.orders() {
+		first, found := (&Sort{Orders: target}).OrderFor(order.Property)
+		if !found {
+			target = append(target, order)
+		} else if first.Direction != order.Direction {
+			conflicts = append(conflicts, order)
+		}
+	}
+	return &Sort{Orders: target}, conflicts
+}
+
+// Equal checks if the provided Sort object has the same orders of the target one
+func (sort *Sort) Equal(other *Sort) bool {
+	if len(sort.orders()) != len(other.orders()) {
+		return false
+	}
+	for i, order := range sort.orders() {
+		if order != other.orders()[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// WithTieBreaker returns a new Sort instance ending with the provided unique property, so that the resulting
+// ordering is total. The property takes the direction of the last order and it's not added when already present.
+func (sort *Sort) WithTieBreaker(property string) *Sort {
+	orders := make([]Order, len(sort.orders()), len(sort.orders())+1)
+	copy(orders, sort.orders())
+	direction := Asc
+	for _, order := range orders {
+		if order.Property == property {
+			return &Sort{Orders: orders}
+		}
+		direction = order.Direction
+	}
+	return &Sort{Orders: append(orders, OrderBy(property, direction))}
+}
+
+// IsEmpty will check if the sort contains properties. A nil Sort is empty.
 func (sort *Sort) IsEmpty() bool {
-	return len(sort.Orders) == 0
+	return len(sort.orders()) == 0
+}
+
+// IsSorted will check if the sort requests any ordering
+func (sort *Sort) IsSorted() bool {
+	return !sort.IsEmpty()
+}
+
+// IsUnsorted will check if the sort does not request any ordering. A nil Sort is unsorted.
+func (sort *Sort) IsUnsorted() bool {
+	return sort.IsEmpty()
+}
+
+// orders returns the sort orders, treating a nil Sort as empty
+func (sort *Sort) orders() []Order {
+	if sort == nil {
+		return nil
+	}
+	return sort.Orders
 }
 
 // Order it's the pairing of a property and a direction.
@@ -90,6 +200,14 @@ func (order Order) WithDirection(direction Direction) Order {
 	return Order{order.Property, direction, order.IgnoreCase, order.NullHandling}
 }
 
+// Reverse returns a new Order instance with the opposite direction
+func (order Order) Reverse() Order {
+	if order.IsDescending() {
+		return order.WithDirection(Asc)
+	}
+	return order.WithDirection(Desc)
+}
+
 // WithIgnoreCase returns a new Order instance with given ignore case flag
 func (order Order) WithIgnoreCase() Order {
 	return Order{order.Property, order.Direction, true, order.NullHandling}
@@ -248,3 +248,101 @@ func TestSortIsEmptyReturnsFalse(t *testing.T) {
 
 	assert.False(t, sort.IsEmpty())
 }
+
+func TestSortWithTieBreakerAppendsPropertyWithLastDirection(t *testing.T) {
+	source := NewSort(OrderByProperty("name"), OrderBy("status", Desc))
+	sort := source.WithTieBreaker("id")
+
+	assert := assert.New(t)
+	assert.Equal(3, len(sort.Orders))
+	assert.Equal(OrderBy("id", Desc), sort.Orders[2])
+	assert.Equal(2, len(source.Orders))
+}
+
+func TestSortWithTieBreakerAlreadyPresent(t *testing.T) {
+	sort := SortBy(Desc, "id", "name").WithTieBreaker("id")
+
+	assert.Equal(t, SortBy(Desc, "id", "name"), sort)
+}
+
+func TestSortWithTieBreakerOnEmptySort(t *testing.T) {
+	sort := EmptySort().WithTieBreaker("id")
+
+	assert.Equal(t, SortByProperties("id"), sort)
+}
+
+func TestSortAndDoesNotAliasReceiver(t *testing.T) {
+	source := &Sort{Orders: make([]Order, 1, 10)}
+	source.Orders[0] = OrderByProperty("prop1")
+	first := source.And(SortByProperties("prop2"))
+	second := source.And(SortByProperties("prop3"))
+
+	assert := assert.New(t)
+	assert.Equal("prop2", first.Orders[1].Property)
+	assert.Equal("prop3", second.Orders[1].Property)
+	assert.Equal(1, len(source.Orders))
+}
+
+func TestOrderReverseWillInvertDirection(t *testing.T) {
+	assert.Equal(t, OrderBy("prop1", Desc), OrderByProperty("prop1").Reverse())
+	assert.Equal(t, OrderBy("prop1", Asc), OrderBy("prop1", Desc).Reverse())
+}
+
+func TestSortReverse(t *testing.T) {
+	source := NewSort(OrderBy("prop1", Desc), OrderByProperty("prop2").NullsFirst())
+	sort := source.Reverse()
+
+	assert := assert.New(t)
+	assert.Equal(NewSort(OrderBy("prop1", Asc), OrderBy("prop2", Desc).NullsFirst()), sort)
+	assert.Equal(Desc, source.Orders[0].Direction)
+}
+
+func TestSortOrderFor(t *testing.T) {
+	sort := NewSort(OrderByProperty("prop1"), OrderBy("prop2", Desc))
+	order, found := sort.OrderFor("prop2")
+
+	assert := assert.New(t)
+	assert.True(found)
+	assert.Equal(OrderBy("prop2", Desc), order)
+	_, found = sort.OrderFor("prop3")
+	assert.False(found)
+}
+
+func TestSortWithout(t *testing.T) {
+	sort := SortByProperties("prop1", "prop2", "prop3").Without("prop1", "prop3")
+
+	assert.Equal(t, SortByProperties("prop2"), sort)
+}
+
+func TestSortFilter(t *testing.T) {
+	sort := NewSort(OrderByProperty("prop1"), OrderBy("prop2", Desc)).Filter(Order.IsDescending)
+
+	assert.Equal(t, SortBy(Desc, "prop2"), sort)
+}
+
+func TestSortDedupeKeepsFirstAndReportsConflicts(t *testing.T) {
+	sort, conflicts := NewSort(OrderByProperty("prop1"), OrderByProperty("prop2"), OrderByProperty("prop1"), OrderBy("prop2", Desc)).Dedupe()
+
+	assert := assert.New(t)
+	assert.Equal(SortByProperties("prop1", "prop2"), sort)
+	assert.Equal([]Order{OrderBy("prop2", Desc)}, conflicts)
+}
+
+func TestSortEqual(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(SortBy(Desc, "prop1", "prop2").Equal(SortBy(Desc, "prop1", "prop2")))
+	assert.False(SortBy(Desc, "prop1", "prop2").Equal(SortBy(Asc, "prop1", "prop2")))
+	assert.False(SortBy(Desc, "prop1").Equal(SortBy(Desc, "prop1", "prop2")))
+	assert.True(EmptySort().Equal(nil))
+}
+
+func TestUnsortedAndNilSortAreEmpty(t *testing.T) {
+	var sort *Sort
+
+	assert := assert.New(t)
+	assert.True(Unsorted().IsUnsorted())
+	assert.False(Unsorted().IsSorted())
+	assert.True(sort.IsEmpty())
+	assert.True(sort.IsUnsorted())
+	assert.True(SortByProperties("prop1").IsSorted())
+}
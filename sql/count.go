@@ -0,0 +1,64 @@
+package sql
+
+import (
+	"context"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// PostgresEstimateQuery reads the number of rows of the table named by the first argument from the planner
+// statistics, to be used as Options.EstimateQuery
+const PostgresEstimateQuery = "SELECT GREATEST(reltuples, 0)::bigint FROM pg_class WHERE oid = to_regclass($1)"
+
+// counter counts the rows returned by a SELECT statement
+type counter struct {
+	db      Querier
+	query   string
+	args    []interface{}
+	options Options
+}
+
+// NewCounter creates the data.Counter for the rows returned by the provided SELECT statement. The estimated count
+// runs the EstimateQuery of given options, falling back to the exact count when it's empty.
+func NewCounter(db Querier, query string, args []interface{}, options Options) data.Counter {
+	return &counter{db, query, args, options}
+}
+
+func (c *counter) Count(ctx context.Context) (int64, error) {
+	counting, err := CountQuery(c.query)
+	if err != nil {
+		return 0, err
+	}
+	return queryInt64(ctx, c.db, counting, c.args)
+}
+
+func (c *counter) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	counting, err := CappedCountQuery(c.query, limit)
+	if err != nil {
+		return 0, err
+	}
+	return queryInt64(ctx, c.db, counting, c.args)
+}
+
+func (c *counter) EstimatedCount(ctx context.Context) (int64, error) {
+	if c.options.EstimateQuery == "" {
+		return c.Count(ctx)
+	}
+	return queryInt64(ctx, c.db, c.options.EstimateQuery, c.options.EstimateArgs)
+}
+
+// queryInt64 runs a query returning a single integer, which is 0 when no row is returned
+func queryInt64(ctx context.Context, db Querier, query string, args []interface{}) (int64, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var value int64
+	if rows.Next() {
+		if err := rows.Scan(&value); err != nil {
+			return 0, err
+		}
+	}
+	return value, rows.Err()
+}
@@ -0,0 +1,133 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// Querier is the interface implemented by *sql.DB, *sql.Tx and *sql.Conn used to run the queries
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Scanner is the interface used to read the columns of the current row
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// ScanFunc reads the current row into a new T value
+type ScanFunc[T any] func(row Scanner) (T, error)
+
+// Options contains the settings used to query a page
+type Options struct {
+	// WindowCount reads the total number of rows with COUNT(*) OVER() in the same round trip of the content
+	WindowCount bool
+	// TieBreaker is a unique column appended to the sort, when not empty, to get a total ordering. Unsorted requests
+	// append it to the ORDER BY clause of the statement, when present.
+	TieBreaker string
+	// Count is the strategy computing the total number of rows, which is data.ExactCount when nil.
+	// It's ignored when WindowCount is set.
+	Count data.CountStrategy
+	// EstimateQuery returns the estimated number of rows for data.EstimatedCount, see PostgresEstimateQuery
+	EstimateQuery string
+	// EstimateArgs are the arguments of EstimateQuery
+	EstimateArgs []interface{}
+}
+
+// FindPage runs the provided SELECT statement for the requested page and counts its rows with a derived count query
+// when needed
+func FindPage[T any](ctx context.Context, db Querier, query string, args []interface{}, pageable *data.Pageable, scan ScanFunc[T]) (*data.Page, error) {
+	return FindPageWithOptions(ctx, db, query, args, pageable, scan, Options{})
+}
+
+// FindPageWithOptions runs the provided SELECT statement for the requested page with given options
+func FindPageWithOptions[T any](ctx context.Context, db Querier, query string, args []interface{}, pageable *data.Pageable, scan ScanFunc[T], options Options) (*data.Page, error) {
+	if options.TieBreaker != "" {
+		var err error
+		if query, pageable, err = tieBreaker(query, pageable, options.TieBreaker); err != nil {
+			return nil, err
+		}
+	}
+	if options.WindowCount {
+		return findWindowCounted(ctx, db, query, args, pageable, scan)
+	}
+	paged, err := ApplyPageable(query, pageable)
+	if err != nil {
+		return nil, err
+	}
+	content, err := queryContent(ctx, db, paged, args, scan, nil)
+	if err != nil {
+		return nil, err
+	}
+	strategy := options.Count
+	if strategy == nil {
+		strategy = data.ExactCount()
+	}
+	return strategy.NewPage(ctx, content, pageable, NewCounter(db, query, args, options))
+}
+
+// FindSlice runs the provided SELECT statement for the requested page without counting its rows: one more row is
+// fetched to find out if a next slice exists
+func FindSlice[T any](ctx context.Context, db Querier, query string, args []interface{}, pageable *data.Pageable, scan ScanFunc[T]) (*data.Slice, error) {
+	paged, err := applyPageable(query, pageable, 1)
+	if err != nil {
+		return nil, err
+	}
+	content, err := queryContent(ctx, db, paged, args, scan, nil)
+	if err != nil {
+		return nil, err
+	}
+	return data.NewSliceFromLookahead(content, pageable)
+}
+
+func findWindowCounted[T any](ctx context.Context, db Querier, query string, args []interface{}, pageable *data.Pageable, scan ScanFunc[T]) (*data.Page, error) {
+	paged, err := WindowCountQuery(query, pageable)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	content, err := queryContent(ctx, db, paged, args, scan, &total)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 && pageable.Offset() > 0 {
+		// requested page is past the end: no row carried the total
+		if total, err = NewCounter(db, query, args, Options{}).Count(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return data.NewPage(content, pageable, total)
+}
+
+func queryContent[T any](ctx context.Context, db Querier, query string, args []interface{}, scan ScanFunc[T], total *int64) ([]T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var row Scanner = rows
+	if total != nil {
+		row = &totalScanner{rows, total}
+	}
+	content := make([]T, 0)
+	for rows.Next() {
+		item, err := scan(row)
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, item)
+	}
+	return content, rows.Err()
+}
+
+// totalScanner reads the trailing total_count column along with the ones requested by the ScanFunc
+type totalScanner struct {
+	rows  *sql.Rows
+	total *int64
+}
+
+func (s *totalScanner) Scan(dest ...interface{}) error {
+	return s.rows.Scan(append(dest, s.total)...)
+}
@@ -0,0 +1,217 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// fakeResults maps the executed queries to the returned rows
+var fakeResults map[string][][]driver.Value
+
+// fakeQueries records the executed queries
+var fakeQueries []string
+
+func init() {
+	sql.Register("fake", fakeDriver{})
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type fakeStmt struct{ query string }
+
+func (fakeStmt) Close() error                                    { return nil }
+func (fakeStmt) NumInput() int                                   { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	fakeQueries = append(fakeQueries, s.query)
+	return &fakeRows{values: fakeResults[s.query]}, nil
+}
+
+type fakeRows struct {
+	values [][]driver.Value
+	index  int
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.values) == 0 {
+		return []string{}
+	}
+	return make([]string, len(r.values[0]))
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.index >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.index])
+	r.index++
+	return nil
+}
+
+func openFake(t *testing.T, results map[string][][]driver.Value) *sql.DB {
+	fakeResults = results
+	fakeQueries = nil
+	db, err := sql.Open("fake", "")
+	assert.Nil(t, err)
+	return db
+}
+
+func scanName(row Scanner) (string, error) {
+	var name string
+	err := row.Scan(&name)
+	return name, err
+}
+
+func TestFindPageRunsCountQuery(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT name FROM users LIMIT 2 OFFSET 2": {{"c"}, {"d"}},
+		"SELECT COUNT(*) FROM users":              {{int64(5)}},
+	})
+	page, err := FindPage(context.Background(), db, "SELECT name FROM users", nil, data.NewPageable(1, 2), scanName)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]string{"c", "d"}, page.Content)
+	assert.Equal(int64(5), page.TotalElements)
+	assert.Equal(3, page.TotalPages)
+	assert.Equal(2, len(fakeQueries))
+}
+
+func TestFindPageSkipsCountOnShortFirstPage(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT name FROM users LIMIT 10 OFFSET 0": {{"a"}, {"b"}},
+	})
+	page, err := FindPage(context.Background(), db, "SELECT name FROM users", nil, data.NewPageable(0, 10), scanName)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(2), page.TotalElements)
+	assert.Equal(1, len(fakeQueries))
+}
+
+func TestFindPageWithWindowCount(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT name, COUNT(*) OVER() AS total_count FROM users LIMIT 2 OFFSET 2": {{"c", int64(7)}, {"d", int64(7)}},
+	})
+	page, err := FindPageWithOptions(context.Background(), db, "SELECT name FROM users", nil, data.NewPageable(1, 2), scanName, Options{WindowCount: true})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]string{"c", "d"}, page.Content)
+	assert.Equal(int64(7), page.TotalElements)
+	assert.Equal(1, len(fakeQueries))
+}
+
+func TestFindPageWithWindowCountPastTheEnd(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT COUNT(*) FROM users": {{int64(3)}},
+	})
+	page, err := FindPageWithOptions(context.Background(), db, "SELECT name FROM users", nil, data.NewPageable(4, 2), scanName, Options{WindowCount: true})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.False(page.HasContent())
+	assert.Equal(int64(3), page.TotalElements)
+	assert.Equal(2, len(fakeQueries))
+}
+
+func TestFindPageWithTieBreaker(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT name FROM users ORDER BY status DESC, id DESC LIMIT 2 OFFSET 0": {{"a"}},
+	})
+	pageable := data.NewSortedPageable(0, 2, data.SortBy(data.Desc, "status"))
+	page, err := FindPageWithOptions(context.Background(), db, "SELECT name FROM users", nil, pageable, scanName, Options{TieBreaker: "id"})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]string{"a"}, page.Content)
+}
+
+func TestFindPageWithTieBreakerKeepsStatementOrder(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT id, name FROM users ORDER BY created_at DESC, id DESC LIMIT 2 OFFSET 0": {{"a"}},
+	})
+	query := "SELECT id, name FROM users ORDER BY created_at DESC"
+	page, err := FindPageWithOptions(context.Background(), db, query, nil, data.NewPageable(0, 2), scanName, Options{TieBreaker: "id"})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]string{"a"}, page.Content)
+}
+
+func TestFindPageWithTieBreakerAlreadyInStatementOrder(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT id, name FROM users ORDER BY name, ID DESC NULLS LAST LIMIT 2 OFFSET 0": {{"a"}},
+	})
+	query := "SELECT id, name FROM users ORDER BY name, ID DESC NULLS LAST"
+	page, err := FindPageWithOptions(context.Background(), db, query, nil, data.NewPageable(0, 2), scanName, Options{TieBreaker: "id"})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]string{"a"}, page.Content)
+}
+
+func TestFindPageWithTieBreakerOnUnsortedStatement(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT name FROM users ORDER BY id ASC LIMIT 2 OFFSET 0": {{"a"}},
+	})
+	page, err := FindPageWithOptions(context.Background(), db, "SELECT name FROM users", nil, data.NewPageable(0, 2), scanName, Options{TieBreaker: "id"})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]string{"a"}, page.Content)
+}
+
+func TestFindPageWithCappedCount(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT name FROM users LIMIT 2 OFFSET 2":                                                          {{"c"}, {"d"}},
+		"SELECT COUNT(*) FROM (SELECT 1 FROM (SELECT name FROM users) capped_query LIMIT 100) count_query": {{int64(100)}},
+	})
+	page, err := FindPageWithOptions(context.Background(), db, "SELECT name FROM users", nil, data.NewPageable(1, 2), scanName, Options{Count: data.CappedCount(100)})
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(100), page.TotalElements)
+	assert.True(page.TotalIsLowerBound)
+}
+
+func TestFindPageWithEstimatedCount(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT name FROM users LIMIT 2 OFFSET 2": {{"c"}, {"d"}},
+		PostgresEstimateQuery:                     {{int64(1200)}},
+	})
+	options := Options{Count: data.EstimatedCount(), EstimateQuery: PostgresEstimateQuery, EstimateArgs: []interface{}{"users"}}
+	page, err := FindPageWithOptions(context.Background(), db, "SELECT name FROM users", nil, data.NewPageable(1, 2), scanName, options)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal(int64(1200), page.TotalElements)
+	assert.True(page.TotalIsEstimated)
+}
+
+func TestFindSlice(t *testing.T) {
+	db := openFake(t, map[string][][]driver.Value{
+		"SELECT name FROM users LIMIT 3 OFFSET 2": {{"c"}, {"d"}, {"e"}},
+	})
+	slice, err := FindSlice(context.Background(), db, "SELECT name FROM users", nil, data.NewPageable(1, 2), scanName)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal([]string{"c", "d"}, slice.Content)
+	assert.True(slice.HasNext())
+	assert.Equal(1, len(fakeQueries))
+}
@@ -0,0 +1,390 @@
+package sql
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	data "gopkg.in/streamtune/data.v1"
+)
+
+// ErrNotSelect is returned when the provided query is not a SELECT statement
+// ErrInvalidProperty is returned when a sort property is not a valid SQL identifier
+var (
+	ErrNotSelect       = errors.New("Invalid query provided: expected a SELECT statement")
+	ErrInvalidProperty = errors.New("Invalid sort property provided: expected <identifier> or <table>.<identifier>")
+)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// CountQuery derives the query counting the rows returned by the provided SELECT statement.
+// The ORDER BY clause is dropped, while DISTINCT, GROUP BY, set operations, LIMIT clauses and select lists or ORDER BY
+// clauses holding placeholders are preserved by wrapping the statement into a sub query, so that the count query
+// takes the same arguments.
+func CountQuery(query string) (string, error) {
+	stmt, err := parse(query)
+	if err != nil {
+		return "", err
+	}
+	if stmt.needsWrapping() {
+		return "SELECT COUNT(*) FROM (" + stmt.withoutOrderBy() + ") count_query", nil
+	}
+	from := stmt.find("FROM")
+	return "SELECT COUNT(*) " + stmt.withoutOrderBy()[stmt.words[from].start:], nil
+}
+
+// ApplyPageable appends ORDER BY, LIMIT and OFFSET clauses for the given pageable to the provided SELECT statement.
+// Unpaged requests get no LIMIT and OFFSET clauses.
+// An ORDER BY clause already present in the statement is replaced when the pageable is sorted, unless it holds
+// placeholders: the statement is then wrapped into a sub query keeping it.
+func ApplyPageable(query string, pageable *data.Pageable) (string, error) {
+	return applyPageable(query, pageable, 0)
+}
+
+// CappedCountQuery derives the query counting the rows returned by the provided SELECT statement up to given limit
+func CappedCountQuery(query string, limit int64) (string, error) {
+	stmt, err := parse(query)
+	if err != nil {
+		return "", err
+	}
+	return "SELECT COUNT(*) FROM (SELECT 1 FROM (" + stmt.withoutOrderBy() + ") capped_query LIMIT " +
+		strconv.FormatInt(limit, 10) + ") count_query", nil
+}
+
+// applyPageable appends the clauses for the given pageable, fetching the provided number of extra rows
+func applyPageable(query string, pageable *data.Pageable, extra int) (string, error) {
+	stmt, err := parse(query)
+	if err != nil {
+		return "", err
+	}
+	orderBy, err := OrderByClause(pageable.SortOrUnsorted())
+	if err != nil {
+		return "", err
+	}
+	paged := stmt.base(orderBy)
+	if stmt.hasLimit() || (orderBy != "" && stmt.orderByHasPlaceholder()) {
+		paged = "SELECT * FROM (" + paged + ") page_query"
+	}
+	return limit(paged, orderBy, pageable, extra)
+}
+
+// WindowCountQuery returns the statement for the requested page adding a trailing total_count column holding
+// the total number of rows computed with COUNT(*) OVER(). Statements needing a sub query to be counted can only be
+// sorted by the names of their output columns.
+func WindowCountQuery(query string, pageable *data.Pageable) (string, error) {
+	stmt, err := parse(query)
+	if err != nil {
+		return "", err
+	}
+	orderBy, err := OrderByClause(pageable.SortOrUnsorted())
+	if err != nil {
+		return "", err
+	}
+	base := stmt.base(orderBy)
+	if stmt.needsWrapping() {
+		return limit("SELECT count_query.*, COUNT(*) OVER() AS total_count FROM ("+base+") count_query", orderBy, pageable, 0)
+	}
+	from := stmt.words[stmt.find("FROM")].start
+	counted := strings.TrimRight(base[:from], " \t\r\n") + ", COUNT(*) OVER() AS total_count " + base[from:]
+	return limit(counted, orderBy, pageable, 0)
+}
+
+// tieBreaker appends the unique column to the sort of the pageable, or to the ORDER BY clause of the statement when
+// the pageable is unsorted, so that the statement keeps its own ordering
+func tieBreaker(query string, pageable *data.Pageable, property string) (string, *data.Pageable, error) {
+	if !identifier.MatchString(property) {
+		return "", nil, ErrInvalidProperty
+	}
+	if !pageable.SortOrUnsorted().IsEmpty() {
+		return query, pageable.WithTieBreaker(property), nil
+	}
+	stmt, err := parse(query)
+	if err != nil {
+		return "", nil, err
+	}
+	at := stmt.orderBy()
+	if at < 0 || stmt.hasLimit() {
+		return query, pageable.WithTieBreaker(property), nil
+	}
+	for _, item := range orderByItems(stmt.text[stmt.words[at+1].end:]) {
+		if strings.EqualFold(item, property) {
+			return stmt.text, pageable, nil
+		}
+	}
+	last := len(stmt.words) - 1
+	if last-1 > at && stmt.words[last-1].upper == "NULLS" {
+		last -= 2
+	}
+	if stmt.words[last].upper == "DESC" {
+		return stmt.text + ", " + property + " DESC", pageable, nil
+	}
+	return stmt.text + ", " + property + " ASC", pageable, nil
+}
+
+// orderByItems returns the expressions of the ORDER BY clause items, without their direction and null handling
+func orderByItems(clause string) []string {
+	var items []string
+	depth, start := 0, 0
+	for i := 0; i <= len(clause); i++ {
+		if i < len(clause) {
+			switch c := clause[i]; {
+			case c == '\'' || c == '"' || c == '`':
+				if end := strings.IndexByte(clause[i+1:], c); end >= 0 {
+					i += end + 1
+				}
+				continue
+			case c == '(':
+				depth++
+				continue
+			case c == ')':
+				depth--
+				continue
+			case c != ',' || depth > 0:
+				continue
+			}
+		}
+		fields := strings.Fields(clause[start:i])
+		for len(fields) > 1 && isOrderModifier(fields[len(fields)-1]) {
+			fields = fields[:len(fields)-1]
+		}
+		items = append(items, strings.Join(fields, " "))
+		start = i + 1
+	}
+	return items
+}
+
+// isOrderModifier check if the word is a direction or null handling keyword of an ORDER BY item
+func isOrderModifier(word string) bool {
+	switch strings.ToUpper(word) {
+	case "ASC", "DESC", "NULLS", "FIRST", "LAST":
+		return true
+	}
+	return false
+}
+
+// OrderByClause renders the ORDER BY clause for the provided sort, returning an empty string for empty sorts
+func OrderByClause(sort *data.Sort) (string, error) {
+	if sort == nil || sort.IsEmpty() {
+		return "", nil
+	}
+	clauses := make([]string, len(sort.Orders))
+	for i, order := range sort.Orders {
+		if !identifier.MatchString(order.Property) {
+			return "", ErrInvalidProperty
+		}
+		clause := order.Property
+		if order.IgnoreCase {
+			clause = "LOWER(" + clause + ")"
+		}
+		if order.IsDescending() {
+			clause += " DESC"
+		} else {
+			clause += " ASC"
+		}
+		switch order.NullHandling {
+		case data.NullsFirst:
+			clause += " NULLS FIRST"
+		case data.NullsLast:
+			clause += " NULLS LAST"
+		}
+		clauses[i] = clause
+	}
+	return "ORDER BY " + strings.Join(clauses, ", "), nil
+}
+
+func limit(query, orderBy string, pageable *data.Pageable, extra int) (string, error) {
+	if orderBy != "" {
+		query += " " + orderBy
+	}
+	if pageable.IsUnpaged() {
+		return query, nil
+	}
+	offset, err := pageable.CheckedOffset()
+	if err != nil {
+		return "", err
+	}
+	return query + " LIMIT " + strconv.Itoa(pageable.Size+extra) + " OFFSET " + strconv.FormatInt(offset, 10), nil
+}
+
+// word is a keyword or identifier found outside of parenthesis, literals and comments
+type word struct {
+	upper      string
+	start, end int
+}
+
+// statement holds a trimmed SELECT statement with its top level words
+type statement struct {
+	text  string
+	words []word
+}
+
+func parse(query string) (*statement, error) {
+	text := strings.TrimRight(strings.TrimSpace(query), "; \t\r\n")
+	stmt := &statement{text: text}
+	depth := 0
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(text[i+1:], c)
+			if end < 0 {
+				return nil, ErrNotSelect
+			}
+			i += end + 2
+		case c == '-' && strings.HasPrefix(text[i:], "--"):
+			end := strings.IndexByte(text[i:], '\n')
+			if end < 0 {
+				end = len(text) - i
+			}
+			i += end
+		case c == '/' && strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return nil, ErrNotSelect
+			}
+			i += end + 4
+		case c == '(':
+			depth++
+			i++
+		case c == ')':
+			depth--
+			i++
+		case isWordStart(c):
+			start := i
+			for i < len(text) && isWordPart(text[i]) {
+				i++
+			}
+			if depth == 0 {
+				stmt.words = append(stmt.words, word{strings.ToUpper(text[start:i]), start, i})
+			}
+		default:
+			i++
+		}
+	}
+	if len(stmt.words) == 0 || (stmt.words[0].upper != "SELECT" && stmt.words[0].upper != "WITH") || stmt.find("SELECT") < 0 {
+		return nil, ErrNotSelect
+	}
+	return stmt, nil
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isWordPart(c byte) bool {
+	return isWordStart(c) || (c >= '0' && c <= '9') || c == '$'
+}
+
+// find returns the index of the first top level word matching the keyword, or -1
+func (stmt *statement) find(keyword string) int {
+	for i, w := range stmt.words {
+		if w.upper == keyword {
+			return i
+		}
+	}
+	return -1
+}
+
+// orderBy returns the index of the last top level ORDER BY word, or -1
+func (stmt *statement) orderBy() int {
+	for i := len(stmt.words) - 2; i >= 0; i-- {
+		if stmt.words[i].upper == "ORDER" && stmt.words[i+1].upper == "BY" {
+			return i
+		}
+	}
+	return -1
+}
+
+// keepsOrderBy check if the ORDER BY clause cannot be dropped from the statement
+func (stmt *statement) keepsOrderBy() bool {
+	return stmt.hasLimit() || stmt.orderByHasPlaceholder()
+}
+
+// orderByHasPlaceholder check if the top level ORDER BY clause holds placeholders
+func (stmt *statement) orderByHasPlaceholder() bool {
+	at := stmt.orderBy()
+	return at >= 0 && hasPlaceholder(stmt.text[stmt.words[at].end:])
+}
+
+func (stmt *statement) hasLimit() bool {
+	for _, keyword := range []string{"LIMIT", "OFFSET", "FETCH"} {
+		if stmt.find(keyword) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// needsWrapping checks if the statement cannot be counted by replacing its select list
+func (stmt *statement) needsWrapping() bool {
+	if stmt.words[0].upper == "WITH" || stmt.keepsOrderBy() || stmt.find("FROM") < 0 {
+		return true
+	}
+	selectAt := stmt.find("SELECT")
+	if selectAt+1 < len(stmt.words) && stmt.words[selectAt+1].upper == "DISTINCT" {
+		return true
+	}
+	for _, keyword := range []string{"GROUP", "HAVING", "UNION", "INTERSECT", "EXCEPT", "WINDOW"} {
+		if stmt.find(keyword) >= 0 {
+			return true
+		}
+	}
+	return hasPlaceholder(stmt.text[stmt.words[selectAt].end:stmt.words[stmt.find("FROM")].start])
+}
+
+// hasPlaceholder checks if the text holds a ?, $n or :name placeholder outside of literals and comments
+func hasPlaceholder(text string) bool {
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(text[i+1:], c)
+			if end < 0 {
+				return false
+			}
+			i += end + 1
+		case c == '-' && strings.HasPrefix(text[i:], "--"):
+			end := strings.IndexByte(text[i:], '\n')
+			if end < 0 {
+				return false
+			}
+			i += end
+		case c == '/' && strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return false
+			}
+			i += end + 3
+		case c == '?':
+			return true
+		case c == '$' && (i == 0 || !isWordPart(text[i-1])) && i+1 < len(text) && text[i+1] >= '0' && text[i+1] <= '9':
+			return true
+		case c == ':' && strings.HasPrefix(text[i:], "::"):
+			// type cast
+			i++
+		case c == ':' && i+1 < len(text) && isWordStart(text[i+1]):
+			return true
+		}
+	}
+	return false
+}
+
+// base returns the statement text to be paged, dropping its own ORDER BY clause when a new one is applied
+func (stmt *statement) base(orderBy string) string {
+	if orderBy == "" {
+		return stmt.text
+	}
+	return stmt.withoutOrderBy()
+}
+
+// withoutOrderBy returns the statement text without the trailing ORDER BY clause. The clause is kept when the
+// statement is limited, since it decides which rows are returned, and when it holds placeholders, whose arguments
+// are still bound.
+func (stmt *statement) withoutOrderBy() string {
+	at := stmt.orderBy()
+	if at < 0 || stmt.keepsOrderBy() {
+		return stmt.text
+	}
+	return strings.TrimRight(stmt.text[:stmt.words[at].start], " \t\r\n")
+}
@@ -0,0 +1,158 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	data "gopkg.in/streamtune/data.v1"
+)
+
+func TestCountQueryReplacesSelectList(t *testing.T) {
+	query, err := CountQuery("SELECT u.id, u.name FROM users u WHERE u.age > ? ORDER BY u.name DESC")
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM users u WHERE u.age > ?", query)
+}
+
+func TestCountQueryIgnoresNestedOrderBy(t *testing.T) {
+	query, err := CountQuery("select id, (select max(x) from y order by x) from users where name = 'order by';")
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) from users where name = 'order by'", query)
+}
+
+func TestCountQueryWrapsGroupBy(t *testing.T) {
+	query, err := CountQuery("SELECT city, COUNT(*) FROM users GROUP BY city ORDER BY city")
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM (SELECT city, COUNT(*) FROM users GROUP BY city) count_query", query)
+}
+
+func TestCountQueryWrapsDistinct(t *testing.T) {
+	query, err := CountQuery("SELECT DISTINCT city FROM users")
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM (SELECT DISTINCT city FROM users) count_query", query)
+}
+
+func TestCountQueryKeepsOrderByWithLimit(t *testing.T) {
+	query, err := CountQuery("SELECT id FROM users ORDER BY id LIMIT 5")
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM (SELECT id FROM users ORDER BY id LIMIT 5) count_query", query)
+}
+
+func TestCountQueryWrapsPlaceholdersInSelectList(t *testing.T) {
+	query, err := CountQuery("SELECT $1::text AS tag, name FROM users WHERE org = $2")
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM (SELECT $1::text AS tag, name FROM users WHERE org = $2) count_query", query)
+
+	query, err = CountQuery("SELECT id, ? AS tag FROM users WHERE org = ?")
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM (SELECT id, ? AS tag FROM users WHERE org = ?) count_query", query)
+
+	query, err = CountQuery("SELECT id, :tag AS tag FROM users WHERE org = :org")
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM (SELECT id, :tag AS tag FROM users WHERE org = :org) count_query", query)
+}
+
+func TestCountQueryIgnoresCastsAndQuotedPlaceholders(t *testing.T) {
+	query, err := CountQuery("SELECT id::text, '?' AS mark FROM users WHERE org = $1")
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM users WHERE org = $1", query)
+}
+
+func TestCountQueryWrapsPlaceholdersInOrderBy(t *testing.T) {
+	query, err := CountQuery("SELECT id FROM places WHERE org = $1 ORDER BY location <-> $2")
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM (SELECT id FROM places WHERE org = $1 ORDER BY location <-> $2) count_query", query)
+}
+
+func TestApplyPageableKeepsOrderByWithPlaceholders(t *testing.T) {
+	pageable := data.NewSortedPageable(0, 10, data.SortByProperties("id"))
+	query, err := ApplyPageable("SELECT id FROM places WHERE org = $1 ORDER BY location <-> $2", pageable)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT * FROM (SELECT id FROM places WHERE org = $1 ORDER BY location <-> $2) page_query ORDER BY id ASC LIMIT 10 OFFSET 0", query)
+}
+
+func TestCountQueryWithInvalidStatement(t *testing.T) {
+	_, err := CountQuery("DELETE FROM users")
+
+	assert.Equal(t, ErrNotSelect, err)
+}
+
+func TestApplyPageableReplacesOrderBy(t *testing.T) {
+	pageable := data.NewSortedPageable(2, 10, data.NewSort(data.OrderBy("name", data.Desc).NullsLast(), data.OrderByProperty("id").WithIgnoreCase()))
+	query, err := ApplyPageable("SELECT * FROM users ORDER BY id", pageable)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT * FROM users ORDER BY name DESC NULLS LAST, LOWER(id) ASC LIMIT 10 OFFSET 20", query)
+}
+
+func TestApplyPageableKeepsOrderByWhenUnsorted(t *testing.T) {
+	query, err := ApplyPageable("SELECT * FROM users ORDER BY id", data.NewPageable(0, 10))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT * FROM users ORDER BY id LIMIT 10 OFFSET 0", query)
+}
+
+func TestApplyPageableWithInvalidProperty(t *testing.T) {
+	_, err := ApplyPageable("SELECT * FROM users", data.NewSortedPageable(0, 10, data.SortByProperties("id; DROP TABLE users")))
+
+	assert.Equal(t, ErrInvalidProperty, err)
+}
+
+func TestWindowCountQueryAddsCountColumn(t *testing.T) {
+	query, err := WindowCountQuery("SELECT id, name FROM users WHERE age > ?", data.NewSortedPageable(1, 5, data.SortByProperties("name")))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT id, name, COUNT(*) OVER() AS total_count FROM users WHERE age > ? ORDER BY name ASC LIMIT 5 OFFSET 5", query)
+}
+
+func TestWindowCountQueryWrapsDistinct(t *testing.T) {
+	query, err := WindowCountQuery("SELECT DISTINCT city FROM users", data.NewPageable(0, 5))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT count_query.*, COUNT(*) OVER() AS total_count FROM (SELECT DISTINCT city FROM users) count_query LIMIT 5 OFFSET 0", query)
+}
+
+func TestApplyPageableWithUnpaged(t *testing.T) {
+	query, err := ApplyPageable("SELECT * FROM users", data.UnpagedWithSort(data.SortByProperties("id")))
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT * FROM users ORDER BY id ASC", query)
+}
+
+func TestApplyPageableWithNilPageable(t *testing.T) {
+	query, err := ApplyPageable("SELECT * FROM users", nil)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT * FROM users", query)
+}
+
+func TestCappedCountQuery(t *testing.T) {
+	query, err := CappedCountQuery("SELECT id FROM users WHERE age > ? ORDER BY id", 1000)
+
+	assert := assert.New(t)
+	assert.Nil(err)
+	assert.Equal("SELECT COUNT(*) FROM (SELECT 1 FROM (SELECT id FROM users WHERE age > ?) capped_query LIMIT 1000) count_query", query)
+}